@@ -7,10 +7,8 @@ import (
 	"reflect"
 
 	"github.com/go-openapi/runtime"
-	httptransport "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
 	"github.com/gophercloud/gophercloud/v2"
-	osClient "github.com/gophercloud/utils/v2/client"
 	"github.com/sapcc/andromeda/client"
 )
 
@@ -29,7 +27,7 @@ func newAndromedaV1(c *Config, eo gophercloud.EndpointOpts) (*client.Andromeda,
 		eo.ApplyDefaults("gtm")
 		endpoint, err = c.OsClient.EndpointLocator(eo)
 		if err != nil {
-			return nil, err
+			return nil, endpointLocatorError(c.OsClient, err, eo)
 		}
 	}
 
@@ -37,13 +35,7 @@ func newAndromedaV1(c *Config, eo gophercloud.EndpointOpts) (*client.Andromeda,
 		return nil, fmt.Errorf("parsing the Andromeda URL failed: %s", err)
 	}
 
-	transport := httptransport.New(aurl.Host, aurl.EscapedPath(), []string{aurl.Scheme})
-
-	if v, ok := c.OsClient.HTTPClient.Transport.(*osClient.RoundTripper); ok && v.Logger != nil {
-		// enable JSON debug for Andromeda
-		transport.SetLogger(logger{"Andromeda"})
-		transport.Debug = true
-	}
+	transport := newOpenAPITransport(c, aurl, "gtm", "Andromeda")
 
 	transport.DefaultAuthentication = runtime.ClientAuthInfoWriterFunc(
 		func(req runtime.ClientRequest, reg strfmt.Registry) error {
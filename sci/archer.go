@@ -1,8 +1,10 @@
 package sci
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"net/url"
 	"reflect"
 
@@ -10,13 +12,14 @@ import (
 	httptransport "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
 	"github.com/gophercloud/gophercloud/v2"
-	osClient "github.com/gophercloud/utils/v2/client"
 	"github.com/sapcc/archer/client"
+	"github.com/sapcc/archer/models"
 )
 
 type archer struct {
 	client.Archer
-	provider *gophercloud.ProviderClient
+	provider  *gophercloud.ProviderClient
+	transport *httptransport.Runtime
 }
 
 func newArcherV1(c *Config, eo gophercloud.EndpointOpts) (*archer, error) {
@@ -34,7 +37,7 @@ func newArcherV1(c *Config, eo gophercloud.EndpointOpts) (*archer, error) {
 		eo.ApplyDefaults("endpoint-services")
 		endpoint, err = c.OsClient.EndpointLocator(eo)
 		if err != nil {
-			return nil, err
+			return nil, endpointLocatorError(c.OsClient, err, eo)
 		}
 	}
 
@@ -42,17 +45,11 @@ func newArcherV1(c *Config, eo gophercloud.EndpointOpts) (*archer, error) {
 		return nil, fmt.Errorf("parsing the Archer URL failed: %s", err)
 	}
 
-	transport := httptransport.New(aurl.Host, aurl.EscapedPath(), []string{aurl.Scheme})
-
-	if v, ok := c.OsClient.HTTPClient.Transport.(*osClient.RoundTripper); ok && v.Logger != nil {
-		// enable JSON debug for Archer
-		transport.SetLogger(logger{"Archer"})
-		transport.Debug = true
-	}
+	transport := newOpenAPITransport(c, aurl, "endpoint-services", "Archer")
 
 	operations := client.New(transport, strfmt.Default)
 
-	return &archer{*operations, c.OsClient}, nil
+	return &archer{*operations, c.OsClient, transport}, nil
 }
 
 func (a *archer) authFunc() runtime.ClientAuthInfoWriterFunc {
@@ -69,3 +66,31 @@ func (a *archer) authFunc() runtime.ClientAuthInfoWriterFunc {
 			return nil
 		})
 }
+
+// lastRequestID returns the request ID of the last Archer API response.
+func (a *archer) lastRequestID() string {
+	return transportLastRequestID(a.transport)
+}
+
+// archerIsNotFound reports whether the Archer API responded with 404.
+func archerIsNotFound(err error) bool {
+	var codeErr interface{ IsCode(int) bool }
+	if errors.As(err, &codeErr) {
+		return codeErr.IsCode(http.StatusNotFound)
+	}
+	return false
+}
+
+// archerErrorMessage returns the message of the Archer API error response, or
+// the error itself, when there is no message, along with the request ID of the
+// last response.
+func archerErrorMessage(c *archer, err error) string {
+	msg := err.Error()
+	var payloadErr interface{ GetPayload() *models.Error }
+	if errors.As(err, &payloadErr) {
+		if p := payloadErr.GetPayload(); p != nil && p.Message != "" {
+			msg = p.Message
+		}
+	}
+	return withRequestID(msg, c.lastRequestID())
+}
@@ -2,11 +2,15 @@ package sci
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
 
 	"github.com/gophercloud/gophercloud/v2"
 	"github.com/gophercloud/utils/v2/openstack/clientconfig"
 	"github.com/sapcc/andromeda/client"
 	"github.com/sapcc/gophercloud-sapcc/v2/clients"
+	"github.com/sapcc/kubernikus/pkg/api/client/operations"
 )
 
 func (c *Config) kubernikusV1Client(ctx context.Context, region string, isAdmin bool) (*kubernikus, error) {
@@ -17,13 +21,103 @@ func (c *Config) kubernikusV1Client(ctx context.Context, region string, isAdmin
 	serviceType := "kubernikus"
 	if isAdmin {
 		serviceType = "kubernikus-kubernikus"
+
+		// fail early, instead of getting a policy error on the first call
+		if c.KubernikusAdminRole != "" {
+			if err := c.kubernikusVerifyAdminRole(ctx, region); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	return newKubernikusV1(c, gophercloud.EndpointOpts{
+	klient, err := newKubernikusV1(c, gophercloud.EndpointOpts{
 		Type:         serviceType,
 		Region:       c.DetermineRegion(region),
 		Availability: clientconfig.GetEndpointType(c.EndpointType),
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	if c.KubernikusAPIVersion != "" {
+		if err := c.kubernikusVerifyAPIVersion(ctx, klient); err != nil {
+			return nil, err
+		}
+	}
+
+	return klient, nil
+}
+
+// kubernikusVerifyAPIVersion verifies once per endpoint, that the Kubernikus
+// endpoint is reachable and serves the pinned API version.
+func (c *Config) kubernikusVerifyAPIVersion(ctx context.Context, klient *kubernikus) error {
+	if _, ok := c.kubernikusVerified.Load(klient.endpoint); ok {
+		return nil
+	}
+
+	info, err := klient.Info(operations.NewInfoParams().WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("the Kubernikus endpoint %s is not reachable: %s", klient.endpoint, err)
+	}
+
+	versions, err := klient.ListAPIVersions(operations.NewListAPIVersionsParams().WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("error listing the API versions of the Kubernikus endpoint %s: %s", klient.endpoint, err)
+	}
+
+	if versions.Payload == nil || !sliceContains(versions.Payload.Versions, c.KubernikusAPIVersion) {
+		var available []string
+		if versions.Payload != nil {
+			available = versions.Payload.Versions
+		}
+		return fmt.Errorf("the Kubernikus endpoint %s doesn't serve the %q API version, available versions: %v", klient.endpoint, c.KubernikusAPIVersion, available)
+	}
+
+	if info.Payload != nil {
+		log.Printf("[DEBUG] Kubernikus endpoint %s runs %s and serves the %s API", klient.endpoint, info.Payload.GitVersion, c.KubernikusAPIVersion)
+	}
+	c.kubernikusVerified.Store(klient.endpoint, struct{}{})
+
+	return nil
+}
+
+// kubernikusVerifyAdminRole verifies once per region and token, that the
+// token, which may also be issued for an application credential, has the role
+// required by the Kubernikus admin API.
+func (c *Config) kubernikusVerifyAdminRole(ctx context.Context, region string) error {
+	identityClient, err := c.IdentityV3Client(ctx, region)
+	if err != nil {
+		return fmt.Errorf("error creating OpenStack identity client: %s", err)
+	}
+
+	key := region + "/" + identityClient.TokenID
+	if v, ok := c.kubernikusAdminVerified.Load(key); ok {
+		if v, ok := v.(error); ok {
+			return v
+		}
+		return nil
+	}
+
+	tokenDetails, err := getTokenDetails(ctx, identityClient)
+	if err != nil {
+		return fmt.Errorf("error getting token details: %s", err)
+	}
+
+	for _, role := range tokenDetails.roles {
+		if role.Name == c.KubernikusAdminRole {
+			c.kubernikusAdminVerified.Store(key, struct{}{})
+			return nil
+		}
+	}
+
+	msg := "the Kubernikus admin API requires the %q role, which is missing in the token"
+	if c.ApplicationCredentialID != "" || c.ApplicationCredentialName != "" {
+		msg += ", make sure the application credential was created with this role"
+	}
+	err = fmt.Errorf(msg, c.KubernikusAdminRole)
+	c.kubernikusAdminVerified.Store(key, err)
+
+	return err
 }
 
 func (c *Config) andromedaV1Client(ctx context.Context, region string) (*client.Andromeda, error) {
@@ -51,5 +145,13 @@ func (c *Config) archerV1Client(ctx context.Context, region string) (*archer, er
 }
 
 func (c *Config) billingClient(ctx context.Context, region string) (*gophercloud.ServiceClient, error) {
-	return c.CommonServiceClientInit(ctx, clients.NewBilling, region, "sapcc-billing")
+	client, err := c.CommonServiceClientInit(ctx, clients.NewBilling, region, "sapcc-billing")
+	var notFound *gophercloud.ErrEndpointNotFound
+	if errors.As(err, &notFound) {
+		return nil, endpointLocatorError(c.OsClient, err, gophercloud.EndpointOpts{
+			Type:   "sapcc-billing",
+			Region: c.DetermineRegion(region),
+		})
+	}
+	return client, err
 }
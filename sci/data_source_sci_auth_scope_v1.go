@@ -0,0 +1,136 @@
+package sci
+
+import (
+	"context"
+	"log"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/tokens"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSCIAuthScopeV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSCIAuthScopeV1Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			// computed
+			"user_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"user_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"user_domain_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"user_domain_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"domain_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"domain_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"project_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"project_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"project_domain_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"project_domain_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"roles": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"role_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSCIAuthScopeV1Read(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	config := meta.(*Config)
+	identityClient, err := config.IdentityV3Client(ctx, GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating OpenStack identity client: %s", err)
+	}
+
+	tokenDetails, err := getTokenDetails(ctx, identityClient)
+	if err != nil {
+		return diag.Errorf("Error getting token details: %s", err)
+	}
+
+	if tokenDetails.user == nil {
+		return diag.Errorf("Error getting token details: token has no user")
+	}
+
+	log.Printf("[DEBUG] Retrieved token scope of user %s", tokenDetails.user.ID)
+
+	d.SetId(tokenDetails.user.ID)
+
+	_ = d.Set("user_id", tokenDetails.user.ID)
+	_ = d.Set("user_name", tokenDetails.user.Name)
+	_ = d.Set("user_domain_id", tokenDetails.user.Domain.ID)
+	_ = d.Set("user_domain_name", tokenDetails.user.Domain.Name)
+
+	if tokenDetails.domain != nil {
+		_ = d.Set("domain_id", tokenDetails.domain.ID)
+		_ = d.Set("domain_name", tokenDetails.domain.Name)
+	}
+
+	if tokenDetails.project != nil {
+		_ = d.Set("project_id", tokenDetails.project.ID)
+		_ = d.Set("project_name", tokenDetails.project.Name)
+		_ = d.Set("project_domain_id", tokenDetails.project.Domain.ID)
+		_ = d.Set("project_domain_name", tokenDetails.project.Domain.Name)
+	}
+
+	_ = d.Set("roles", flattenAuthScopeRoles(tokenDetails.roles))
+	_ = d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func flattenAuthScopeRoles(roles []tokens.Role) []map[string]any {
+	res := make([]map[string]any, len(roles))
+	for i, role := range roles {
+		res[i] = map[string]any{
+			"role_id":   role.ID,
+			"role_name": role.Name,
+		}
+	}
+	return res
+}
@@ -0,0 +1,168 @@
+package sci
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sapcc/gophercloud-sapcc/v2/networking/v2/bgpvpn/interconnections"
+)
+
+func dataSourceSCIBGPVPNInterconnectionV2() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSCIBGPVPNInterconnectionV2Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"interconnection_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"local_resource_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"remote_resource_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"remote_region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			// computed
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"remote_interconnection_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"local_parameters": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"project_id": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"remote_parameters": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"project_id": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSCIBGPVPNInterconnectionV2Read(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	config := meta.(*Config)
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
+	}
+
+	listOpts := interconnections.ListOpts{}
+
+	if v, ok := d.GetOk("interconnection_id"); ok {
+		listOpts.ID = []string{v.(string)}
+	}
+
+	if v, ok := d.GetOk("name"); ok {
+		listOpts.Name = []string{v.(string)}
+	}
+
+	if v, ok := d.GetOk("project_id"); ok {
+		listOpts.ProjectID = []string{v.(string)}
+	}
+
+	if v, ok := d.GetOk("local_resource_id"); ok {
+		listOpts.LocalResourceID = []string{v.(string)}
+	}
+
+	if v, ok := d.GetOk("remote_resource_id"); ok {
+		listOpts.RemoteResourceID = []string{v.(string)}
+	}
+
+	if v, ok := d.GetOk("remote_region"); ok {
+		listOpts.RemoteRegion = []string{v.(string)}
+	}
+
+	if v, ok := d.GetOk("state"); ok {
+		listOpts.State = []string{v.(string)}
+	}
+
+	pages, err := interconnections.List(networkingClient, listOpts).AllPages(ctx)
+	if err != nil {
+		return diag.Errorf("Unable to list BGP VPN interconnections: %s", err)
+	}
+
+	allInterConns, err := interconnections.ExtractInterconnections(pages)
+	if err != nil {
+		return diag.Errorf("Unable to retrieve BGP VPN interconnections: %s", err)
+	}
+
+	interConn, err := exactlyOne(allInterConns, "BGP VPN interconnection", func(i interconnections.Interconnection) string {
+		return describeMatch(i.ID, i.Name)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Retrieved BGP VPN interconnection %s: %#v", interConn.ID, interConn)
+	d.SetId(interConn.ID)
+
+	_ = d.Set("interconnection_id", interConn.ID)
+	_ = d.Set("name", interConn.Name)
+	_ = d.Set("type", interConn.Type)
+	_ = d.Set("project_id", interConn.ProjectID)
+	_ = d.Set("local_resource_id", interConn.LocalResourceID)
+	_ = d.Set("remote_resource_id", interConn.RemoteResourceID)
+	_ = d.Set("remote_region", interConn.RemoteRegion)
+	_ = d.Set("remote_interconnection_id", interConn.RemoteInterconnectionID)
+	_ = d.Set("state", interConn.State)
+	_ = d.Set("local_parameters", []map[string][]string{{"project_id": interConn.LocalParameters.ProjectID}})
+	_ = d.Set("remote_parameters", []map[string][]string{{"project_id": interConn.RemoteParameters.ProjectID}})
+	_ = d.Set("region", GetRegion(d, config))
+
+	return nil
+}
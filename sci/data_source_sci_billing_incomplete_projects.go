@@ -0,0 +1,131 @@
+package sci
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/projects"
+)
+
+func dataSourceSCIBillingIncompleteProjects() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSCIBillingIncompleteProjectsRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"domain_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"exclude_deleted": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"project_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"projects": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"project_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"project_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"missing_attributes": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSCIBillingIncompleteProjectsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	config := meta.(*Config)
+	billing, err := config.billingClient(ctx, GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating OpenStack billing client: %s", err)
+	}
+
+	domainID := d.Get("domain_id").(string)
+	if domainID == "" {
+		// expecting to get current scope domain
+		identityClient, err := config.IdentityV3Client(ctx, GetRegion(d, config))
+		if err != nil {
+			return diag.Errorf("Error creating OpenStack identity client: %s", err)
+		}
+
+		tokenDetails, err := getTokenDetails(ctx, identityClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		switch {
+		case tokenDetails.domain != nil:
+			domainID = tokenDetails.domain.ID
+		case tokenDetails.project != nil:
+			domainID = tokenDetails.project.Domain.ID
+		default:
+			return diag.Errorf("Error getting billing domain scope: token has no domain or project scope")
+		}
+	}
+
+	opts := projects.ListOpts{
+		ExcludeDeleted: d.Get("exclude_deleted").(bool),
+	}
+	allPages, err := projects.List(billing, opts).AllPages(ctx)
+	if err != nil {
+		return diag.Errorf("Error listing billing project masterdata: %s", err)
+	}
+	allProjects, err := projects.ExtractProjects(allPages)
+	if err != nil {
+		return diag.Errorf("Error extracting billing project masterdata: %s", err)
+	}
+
+	log.Printf("[DEBUG] Retrieved %d project masterdata entries", len(allProjects))
+
+	var projectIDs []string
+	var incomplete []map[string]any
+	for _, project := range allProjects {
+		if project.DomainID != domainID || project.IsComplete {
+			continue
+		}
+		projectIDs = append(projectIDs, project.ProjectID)
+		incomplete = append(incomplete, map[string]any{
+			"project_id":         project.ProjectID,
+			"project_name":       project.ProjectName,
+			"missing_attributes": project.MissingAttributes,
+		})
+	}
+
+	d.SetId(domainID)
+
+	_ = d.Set("domain_id", domainID)
+	_ = d.Set("project_ids", projectIDs)
+	_ = d.Set("projects", incomplete)
+	_ = d.Set("region", GetRegion(d, config))
+
+	return nil
+}
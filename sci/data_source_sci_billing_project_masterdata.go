@@ -2,11 +2,17 @@ package sci
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strings"
 	"time"
 
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/domains"
+	identityprojects "github.com/gophercloud/gophercloud/v2/openstack/identity/v3/projects"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/projects"
 )
 
@@ -22,23 +28,33 @@ func dataSourceSCIBillingProjectMasterdata() *schema.Resource {
 			},
 
 			"project_id": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"project_name"},
 			},
 
 			"project_name": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"project_id"},
 			},
 
 			"domain_id": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				RequiredWith:  []string{"project_name"},
+				ConflictsWith: []string{"domain_name"},
 			},
 
 			"domain_name": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				RequiredWith:  []string{"project_name"},
+				ConflictsWith: []string{"domain_id"},
 			},
 
 			"parent_id": {
@@ -229,10 +245,57 @@ func dataSourceSCIBillingProjectMasterdata() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"required_attributes": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(billingProjectRequiredAttributes, false),
+				},
+			},
+
+			"is_compliant": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 		},
 	}
 }
 
+// billingProjectRequiredAttributes lists the attributes, which can be
+// required to be non-empty by the required_attributes argument.
+var billingProjectRequiredAttributes = []string{
+	"parent_id",
+	"project_type",
+	"description",
+	"revenue_relevance",
+	"business_criticality",
+	"number_of_endusers",
+	"additional_information",
+	"responsible_primary_contact_id",
+	"responsible_primary_contact_email",
+	"responsible_operator_id",
+	"responsible_operator_email",
+	"responsible_inventory_role_id",
+	"responsible_inventory_role_email",
+	"responsible_infrastructure_coordinator_id",
+	"responsible_infrastructure_coordinator_email",
+	"cost_object",
+	"environment",
+	"soft_license_mode",
+	"type_of_data",
+	"gpu_enabled",
+	"contains_pii_dpp_hr",
+	"contains_external_customer_data",
+	"ext_certification.c5",
+	"ext_certification.iso",
+	"ext_certification.pci",
+	"ext_certification.soc1",
+	"ext_certification.soc2",
+	"ext_certification.sox",
+}
+
 func dataSourceSCIBillingProjectMasterdataRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	config := meta.(*Config)
 	billing, err := config.billingClient(ctx, GetRegion(d, config))
@@ -241,6 +304,17 @@ func dataSourceSCIBillingProjectMasterdataRead(ctx context.Context, d *schema.Re
 	}
 
 	projectID := d.Get("project_id").(string)
+	if v := d.Get("project_name").(string); projectID == "" && v != "" {
+		identityClient, err := config.IdentityV3Client(ctx, GetRegion(d, config))
+		if err != nil {
+			return diag.Errorf("Error creating OpenStack identity client: %s", err)
+		}
+
+		projectID, err = billingResolveProjectID(ctx, identityClient, v, d.Get("domain_id").(string), d.Get("domain_name").(string))
+		if err != nil {
+			return diag.Errorf("Error resolving billing project: %s", err)
+		}
+	}
 	if projectID == "" {
 		// first call, expecting to get current scope project
 		identityClient, err := config.IdentityV3Client(ctx, GetRegion(d, config))
@@ -303,8 +377,87 @@ func dataSourceSCIBillingProjectMasterdataRead(ctx context.Context, d *schema.Re
 	_ = d.Set("is_complete", project.IsComplete)
 	_ = d.Set("missing_attributes", project.MissingAttributes)
 	_ = d.Set("collector", project.Collector)
+	_ = d.Set("is_compliant", billingProjectIsCompliant(d, project.IsComplete))
 
 	_ = d.Set("region", GetRegion(d, config))
 
 	return nil
 }
+
+// billingResolveProjectID resolves the project name to an ID. The domain is
+// optional and can be given by ID or by name.
+func billingResolveProjectID(ctx context.Context, identityClient *gophercloud.ServiceClient, name, domainID, domainName string) (string, error) {
+	if domainID == "" && domainName != "" {
+		allPages, err := domains.List(identityClient, domains.ListOpts{Name: domainName}).AllPages(ctx)
+		if err != nil {
+			return "", fmt.Errorf("error listing domains: %s", err)
+		}
+		allDomains, err := domains.ExtractDomains(allPages)
+		if err != nil {
+			return "", fmt.Errorf("error extracting domains: %s", err)
+		}
+		domain, err := exactlyOne(allDomains, "domain", func(v domains.Domain) string {
+			return describeMatch(v.ID, v.Name)
+		})
+		if err != nil {
+			return "", err
+		}
+		domainID = domain.ID
+	}
+
+	allPages, err := identityprojects.List(identityClient, identityprojects.ListOpts{Name: name, DomainID: domainID}).AllPages(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error listing projects: %s", err)
+	}
+	allProjects, err := identityprojects.ExtractProjects(allPages)
+	if err != nil {
+		return "", fmt.Errorf("error extracting projects: %s", err)
+	}
+	project, err := exactlyOne(allProjects, "project", func(v identityprojects.Project) string {
+		return describeMatch(v.ID, "domain "+v.DomainID)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return project.ID, nil
+}
+
+// billingProjectIsCompliant returns true, when the masterdata is complete and
+// all required attributes are non-empty. Boolean attributes must be true.
+func billingProjectIsCompliant(d *schema.ResourceData, isComplete bool) bool {
+	if !isComplete {
+		return false
+	}
+
+	for _, attr := range expandToStringSlice(d.Get("required_attributes").([]any)) {
+		if attr == "cost_object" {
+			// a cost object is either inherited or named
+			if !d.Get("cost_object.0.inherited").(bool) && d.Get("cost_object.0.name").(string) == "" {
+				return false
+			}
+			continue
+		}
+		if k, ok := strings.CutPrefix(attr, "ext_certification."); ok {
+			attr = "ext_certification.0." + k
+		}
+		switch v := d.Get(attr).(type) {
+		case string:
+			if v == "" {
+				return false
+			}
+		case int:
+			if v == 0 {
+				return false
+			}
+		case bool:
+			if !v {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -3,6 +3,7 @@ package sci
 import (
 	"context"
 
+	"github.com/go-openapi/runtime"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -93,6 +94,10 @@ func dataSourceSCIEndpointServiceV1() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"protocol": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"proxy_protocol": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -158,23 +163,24 @@ func dataSourceSCIEndpointServiceV1Read(ctx context.Context, d *schema.ResourceD
 
 	// List the services
 	listOpts := &service.GetServiceParams{
-		Tags: expandToStringSlice(d.Get("tags").([]any)),
+		Tags:    expandToStringSlice(d.Get("tags").([]any)),
+		Context: ctx,
 	}
 	if v, ok := d.GetOk("project_id"); ok {
 		v := v.(string)
 		listOpts.ProjectID = &v
 	}
 
-	services, err := client.GetService(listOpts, c.authFunc())
+	allServices, err := archerListServices(client, listOpts, c.authFunc())
 	if err != nil {
 		return diag.Errorf("error listing Archer services: %s", err)
 	}
 
-	if services.Payload == nil || len(services.Payload.Items) == 0 {
+	if len(allServices) == 0 {
 		return diag.Errorf("Archer services not found")
 	}
 
-	filteredServices := make([]models.Service, 0, len(services.Payload.Items))
+	filteredServices := make([]models.Service, 0, len(allServices))
 
 	// define filter values
 	var name, description, availabilityZone, networkID, provider, visibility, host, status *string
@@ -229,7 +235,7 @@ func dataSourceSCIEndpointServiceV1Read(ctx context.Context, d *schema.ResourceD
 		ipAddresses = expandToStringSlice(v.([]any))
 	}
 
-	for _, svc := range services.Payload.Items {
+	for _, svc := range allServices {
 		if svc == nil {
 			continue
 		}
@@ -275,16 +281,13 @@ func dataSourceSCIEndpointServiceV1Read(ctx context.Context, d *schema.ResourceD
 		filteredServices = append(filteredServices, *svc)
 	}
 
-	if len(filteredServices) == 0 {
-		return diag.Errorf("Archer services not found")
-	}
-
-	if len(filteredServices) > 1 {
-		return diag.Errorf("found more than one Archer services: %v", filteredServices)
+	svc, err := exactlyOne(filteredServices, "Archer service", func(svc models.Service) string {
+		return describeMatch(string(svc.ID), svc.Name)
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	svc := filteredServices[0]
-
 	d.SetId(string(svc.ID))
 
 	_ = d.Set("enabled", ptrValue(svc.Enabled))
@@ -296,6 +299,7 @@ func dataSourceSCIEndpointServiceV1Read(ctx context.Context, d *schema.ResourceD
 	_ = d.Set("project_id", svc.ProjectID)
 	_ = d.Set("all_tags", svc.Tags)
 	_ = d.Set("service_provider", ptrValue(svc.Provider))
+	_ = d.Set("protocol", ptrValue(svc.Protocol))
 	_ = d.Set("proxy_protocol", ptrValue(svc.ProxyProtocol))
 	_ = d.Set("require_approval", ptrValue(svc.RequireApproval))
 	_ = d.Set("visibility", ptrValue(svc.Visibility))
@@ -310,3 +314,39 @@ func dataSourceSCIEndpointServiceV1Read(ctx context.Context, d *schema.ResourceD
 
 	return nil
 }
+
+// archerListServices returns the services of all pages, so that a service can
+// be found by its name regardless of the amount of visible services.
+func archerListServices(client service.ClientService, opts *service.GetServiceParams, authInfo runtime.ClientAuthInfoWriter) ([]*models.Service, error) {
+	var allServices []*models.Service
+	for {
+		res, err := client.GetService(opts, authInfo)
+		if err != nil {
+			return nil, err
+		}
+		if res.Payload == nil || len(res.Payload.Items) == 0 {
+			return allServices, nil
+		}
+
+		allServices = append(allServices, res.Payload.Items...)
+
+		if !archerHasNextPage(res.Payload.Links) {
+			return allServices, nil
+		}
+
+		last := res.Payload.Items[len(res.Payload.Items)-1]
+		if last == nil {
+			return allServices, nil
+		}
+		opts.Marker = &last.ID
+	}
+}
+
+func archerHasNextPage(links []*models.Link) bool {
+	for _, link := range links {
+		if link != nil && link.Rel == "next" {
+			return true
+		}
+	}
+	return false
+}
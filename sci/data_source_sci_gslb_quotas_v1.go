@@ -0,0 +1,188 @@
+package sci
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sapcc/andromeda/client/administrative"
+)
+
+// andromedaQuotasConcurrency limits the number of parallel quota requests.
+const andromedaQuotasConcurrency = 8
+
+func dataSourceSCIGSLBQuotasV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSCIGSLBQuotasV1Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"project_ids": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+			},
+
+			// computed
+			"quotas": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"project_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"datacenter": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"domain_akamai": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"domain_f5": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"member": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"monitor": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"pool": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"in_use_datacenter": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"in_use_domain_akamai": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"in_use_domain_f5": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"in_use_member": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"in_use_monitor": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"in_use_pool": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSCIGSLBQuotasV1Read(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	config := meta.(*Config)
+	c, err := config.andromedaV1Client(ctx, GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("error creating Andromeda client: %s", err)
+	}
+	client := c.Administrative
+
+	projectIDs := expandToStringSlice(d.Get("project_ids").([]any))
+	quotas := make([]*administrative.GetQuotasProjectIDOKBody, len(projectIDs))
+	errs := make([]error, len(projectIDs))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, andromedaQuotasConcurrency)
+	for i, projectID := range projectIDs {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			opts := &administrative.GetQuotasProjectIDParams{
+				ProjectID: projectID,
+				Context:   ctx,
+			}
+			res, err := client.GetQuotasProjectID(opts)
+			if err != nil {
+				errs[i] = fmt.Errorf("error reading Andromeda quota for project %s: %s", projectID, err)
+				return
+			}
+			if res == nil || res.Payload == nil {
+				errs[i] = fmt.Errorf("error reading Andromeda quota for project %s: empty response", projectID)
+				return
+			}
+			quotas[i] = res.Payload
+		})
+	}
+	wg.Wait()
+
+	var diags diag.Diagnostics
+	for _, err := range errs {
+		if err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Retrieved %d Andromeda quotas", len(quotas))
+
+	d.SetId(andromedaQuotasHash(projectIDs))
+	_ = d.Set("quotas", andromedaFlattenQuotas(projectIDs, quotas))
+	_ = d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func andromedaFlattenQuotas(projectIDs []string, quotas []*administrative.GetQuotasProjectIDOKBody) []map[string]any {
+	res := make([]map[string]any, len(quotas))
+	for i, q := range quotas {
+		res[i] = map[string]any{
+			"project_id":           projectIDs[i],
+			"datacenter":           ptrValue(q.Quota.Datacenter),
+			"domain_akamai":        ptrValue(q.Quota.DomainAkamai),
+			"domain_f5":            ptrValue(q.Quota.DomainF5),
+			"member":               ptrValue(q.Quota.Member),
+			"monitor":              ptrValue(q.Quota.Monitor),
+			"pool":                 ptrValue(q.Quota.Pool),
+			"in_use_datacenter":    q.Quota.InUseDatacenter,
+			"in_use_domain_akamai": q.Quota.InUseDomainAkamai,
+			"in_use_domain_f5":     q.Quota.InUseDomainF5,
+			"in_use_member":        q.Quota.InUseMember,
+			"in_use_monitor":       q.Quota.InUseMonitor,
+			"in_use_pool":          q.Quota.InUsePool,
+		}
+	}
+	return res
+}
+
+func andromedaQuotasHash(projectIDs []string) string {
+	h := sha256.New()
+	for _, projectID := range projectIDs {
+		h.Write([]byte(projectID))
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
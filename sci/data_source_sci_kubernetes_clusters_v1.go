@@ -0,0 +1,138 @@
+package sci
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sapcc/kubernikus/pkg/api/client/operations"
+	"github.com/sapcc/kubernikus/pkg/api/models"
+)
+
+func dataSourceSCIKubernetesClustersV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSCIKubernetesClustersV1Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"is_admin": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			// computed
+			"clusters": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"phase": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"apiserver_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"no_cloud": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"apiserver": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"node_pools": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSCIKubernetesClustersV1Read(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	config := meta.(*Config)
+	log.Printf("[KUBERNETES] Listing Kubernikus Klusters in project %s", config.TenantID)
+
+	klient, err := config.kubernikusV1Client(ctx, GetRegion(d, config), d.Get("is_admin").(bool))
+	if err != nil {
+		return diag.Errorf("Error creating Kubernikus client: %s", err)
+	}
+
+	result, err := klient.ListClusters(operations.NewListClustersParams().WithContext(ctx), klient.authFunc())
+	if err != nil {
+		switch res := err.(type) {
+		case *operations.ListClustersDefault:
+			return diag.Errorf("Error listing Kubernikus clusters: %s", res.Payload.Message)
+		case error:
+			return diag.Errorf("Error listing Kubernikus clusters: %s", err)
+		}
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Retrieved %d Kubernikus clusters", len(result.Payload))
+
+	d.SetId(kubernikusClustersV1Hash(result.Payload))
+	if err = d.Set("clusters", flattenKubernikusClustersV1(result.Payload)); err != nil {
+		return diag.Errorf("Unable to set clusters: %s", err)
+	}
+	_ = d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func flattenKubernikusClustersV1(klusters []*models.Kluster) []map[string]any {
+	res := make([]map[string]any, 0, len(klusters))
+	for _, kluster := range klusters {
+		if kluster == nil {
+			continue
+		}
+
+		nodePools := make([]string, len(kluster.Spec.NodePools))
+		for i, pool := range kluster.Spec.NodePools {
+			nodePools[i] = pool.Name
+		}
+
+		res = append(res, map[string]any{
+			"name":              kluster.Name,
+			"phase":             string(kluster.Status.Phase),
+			"version":           kluster.Spec.Version,
+			"apiserver_version": kluster.Status.ApiserverVersion,
+			"no_cloud":          kluster.Spec.NoCloud,
+			"apiserver":         kluster.Status.Apiserver,
+			"node_pools":        nodePools,
+		})
+	}
+	return res
+}
+
+func kubernikusClustersV1Hash(klusters []*models.Kluster) string {
+	h := sha256.New()
+	for _, kluster := range klusters {
+		if kluster != nil {
+			h.Write([]byte(kluster.Name))
+		}
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
@@ -6,10 +6,13 @@ package sci
 
 import (
 	"context"
+	"encoding/json"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/layer3/routers"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/ports"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -22,11 +25,38 @@ type GatewayInfo struct {
 	ExternalPortID   string                    `json:"external_port_id,omitempty"`
 }
 
+type ConntrackHelper struct {
+	Helper   string `json:"helper"`
+	Protocol string `json:"protocol"`
+	Port     int    `json:"port"`
+}
+
 type ccRouter struct {
-	CCGatewayInfo GatewayInfo `json:"external_gateway_info"`
+	CCGatewayInfo    GatewayInfo       `json:"external_gateway_info"`
+	ConntrackHelpers []ConntrackHelper `json:"conntrack_helpers,omitempty"`
 	routers.Router
 }
 
+// UnmarshalJSON overrides the promoted upstream method, which would skip the
+// SAP Cloud Infrastructure specific fields.
+func (r *ccRouter) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &r.Router); err != nil {
+		return err
+	}
+
+	var s struct {
+		CCGatewayInfo    GatewayInfo       `json:"external_gateway_info"`
+		ConntrackHelpers []ConntrackHelper `json:"conntrack_helpers,omitempty"`
+	}
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	r.CCGatewayInfo = s.CCGatewayInfo
+	r.ConntrackHelpers = s.ConntrackHelpers
+
+	return nil
+}
+
 func dataSourceSCINetworkingRouterV2() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceSCINetworkingRouterV2Read,
@@ -66,6 +96,7 @@ func dataSourceSCINetworkingRouterV2() *schema.Resource {
 			},
 			"external_network_id": {
 				Type:     schema.TypeString,
+				Optional: true,
 				Computed: true,
 			},
 			"external_port_id": {
@@ -77,6 +108,14 @@ func dataSourceSCINetworkingRouterV2() *schema.Resource {
 				Computed: true,
 				Optional: true,
 			},
+			"snat_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"qos_policy_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"availability_zone_hints": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -98,11 +137,95 @@ func dataSourceSCINetworkingRouterV2() *schema.Resource {
 					},
 				},
 			},
+			"routes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"destination_cidr": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"next_hop": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"conntrack_helpers": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"helper": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"protocol": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"port": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"interfaces": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"port_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"subnet_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ip_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"most_recent": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"updated_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"tags": {
 				Type:     schema.TypeSet,
 				Optional: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+			"tags_any": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"not_tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"not_tags_any": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 			"all_tags": {
 				Type:     schema.TypeSet,
 				Computed: true,
@@ -119,6 +242,91 @@ func dataSourceSCINetworkingRouterV2Read(ctx context.Context, d *schema.Resource
 		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
+	listOpts := networkingRouterV2ListOpts(d)
+
+	pages, err := routers.List(networkingClient, listOpts).AllPages(ctx)
+	if err != nil {
+		return diag.Errorf("Unable to list Routers: %s", err)
+	}
+
+	var allRouters []ccRouter
+	err = routers.ExtractRoutersInto(pages, &allRouters)
+	if err != nil {
+		return diag.Errorf("Unable to retrieve Routers: %s", err)
+	}
+
+	// the Neutron API doesn't support filtering by the external gateway
+	if v, ok := d.GetOk("external_network_id"); ok {
+		allRouters = filterNetworkingRoutersV2ByExternalNetwork(allRouters, v.(string))
+	}
+
+	if len(allRouters) > 1 && d.Get("most_recent").(bool) {
+		allRouters = []ccRouter{mostRecentNetworkingRouterV2(allRouters)}
+	}
+
+	router, err := exactlyOne(allRouters, "router", func(r ccRouter) string {
+		return describeMatch(r.ID, r.Name)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Retrieved Router %s: %+v", router.ID, router)
+	d.SetId(router.ID)
+
+	_ = d.Set("name", router.Name)
+	_ = d.Set("description", router.Description)
+	_ = d.Set("admin_state_up", router.AdminStateUp)
+	_ = d.Set("distributed", router.Distributed)
+	_ = d.Set("status", router.Status)
+	_ = d.Set("tenant_id", router.TenantID)
+	_ = d.Set("external_network_id", router.CCGatewayInfo.NetworkID)
+	_ = d.Set("external_port_id", router.CCGatewayInfo.ExternalPortID)
+	// don't store false, when the API doesn't report the SNAT state
+	if router.CCGatewayInfo.EnableSNAT != nil {
+		_ = d.Set("enable_snat", *router.CCGatewayInfo.EnableSNAT)
+	} else {
+		_ = d.Set("enable_snat", nil)
+	}
+	_ = d.Set("snat_enabled", networkingRouterV2SNATEnabled(router.CCGatewayInfo))
+	_ = d.Set("qos_policy_id", router.CCGatewayInfo.QoSPolicyID)
+	_ = d.Set("all_tags", router.Tags)
+	_ = d.Set("created_at", router.CreatedAt.Format(time.RFC3339))
+	_ = d.Set("updated_at", router.UpdatedAt.Format(time.RFC3339))
+	_ = d.Set("region", GetRegion(d, config))
+
+	if err := d.Set("availability_zone_hints", router.AvailabilityZoneHints); err != nil {
+		log.Printf("[DEBUG] Unable to set availability_zone_hints: %s", err)
+	}
+
+	if err = d.Set("external_fixed_ip", flattenNetworkingRouterV2ExternalFixedIPs(router.GatewayInfo.ExternalFixedIPs)); err != nil {
+		log.Printf("[DEBUG] Unable to set external_fixed_ip: %s", err)
+	}
+
+	if err = d.Set("routes", flattenNetworkingRouterV2Routes(router.Routes)); err != nil {
+		log.Printf("[DEBUG] Unable to set routes: %s", err)
+	}
+
+	if err = d.Set("conntrack_helpers", flattenNetworkingRouterV2ConntrackHelpers(router.ConntrackHelpers)); err != nil {
+		log.Printf("[DEBUG] Unable to set conntrack_helpers: %s", err)
+	}
+
+	pages, err = ports.List(networkingClient, ports.ListOpts{DeviceID: router.ID}).AllPages(ctx)
+	if err != nil {
+		return diag.Errorf("Unable to list the ports of Router %s: %s", router.ID, err)
+	}
+	allPorts, err := ports.ExtractPorts(pages)
+	if err != nil {
+		return diag.Errorf("Unable to retrieve the ports of Router %s: %s", router.ID, err)
+	}
+
+	if err = d.Set("interfaces", flattenNetworkingRouterV2Interfaces(allPorts)); err != nil {
+		log.Printf("[DEBUG] Unable to set interfaces: %s", err)
+	}
+	return nil
+}
+
+func networkingRouterV2ListOpts(d *schema.ResourceData) routers.ListOpts {
 	listOpts := routers.ListOpts{}
 
 	if v, ok := d.GetOk("router_id"); ok {
@@ -156,55 +364,108 @@ func dataSourceSCINetworkingRouterV2Read(ctx context.Context, d *schema.Resource
 		listOpts.Tags = strings.Join(tags, ",")
 	}
 
-	pages, err := routers.List(networkingClient, listOpts).AllPages(ctx)
-	if err != nil {
-		return diag.Errorf("Unable to list Routers: %s", err)
+	if v := expandToStringSlice(d.Get("tags_any").(*schema.Set).List()); len(v) > 0 {
+		listOpts.TagsAny = strings.Join(v, ",")
 	}
 
-	var allRouters []ccRouter
-	err = routers.ExtractRoutersInto(pages, &allRouters)
-	if err != nil {
-		return diag.Errorf("Unable to retrieve Routers: %s", err)
+	if v := expandToStringSlice(d.Get("not_tags").(*schema.Set).List()); len(v) > 0 {
+		listOpts.NotTags = strings.Join(v, ",")
 	}
 
-	if len(allRouters) < 1 {
-		return diag.Errorf("No Router found")
+	if v := expandToStringSlice(d.Get("not_tags_any").(*schema.Set).List()); len(v) > 0 {
+		listOpts.NotTagsAny = strings.Join(v, ",")
 	}
 
-	if len(allRouters) > 1 {
-		return diag.Errorf("More than one Router found")
-	}
+	return listOpts
+}
 
-	router := allRouters[0]
+// mostRecentNetworkingRouterV2 returns the most recently created router, the
+// update time breaks ties.
+func mostRecentNetworkingRouterV2(allRouters []ccRouter) ccRouter {
+	res := allRouters[0]
+	for _, router := range allRouters[1:] {
+		if router.CreatedAt.After(res.CreatedAt) ||
+			(router.CreatedAt.Equal(res.CreatedAt) && router.UpdatedAt.After(res.UpdatedAt)) {
+			res = router
+		}
+	}
+	return res
+}
 
-	log.Printf("[DEBUG] Retrieved Router %s: %+v", router.ID, router)
-	d.SetId(router.ID)
+func filterNetworkingRoutersV2ByExternalNetwork(allRouters []ccRouter, networkID string) []ccRouter {
+	var res []ccRouter
+	for _, router := range allRouters {
+		if router.CCGatewayInfo.NetworkID == networkID {
+			res = append(res, router)
+		}
+	}
+	return res
+}
 
-	_ = d.Set("name", router.Name)
-	_ = d.Set("description", router.Description)
-	_ = d.Set("admin_state_up", router.AdminStateUp)
-	_ = d.Set("distributed", router.Distributed)
-	_ = d.Set("status", router.Status)
-	_ = d.Set("tenant_id", router.TenantID)
-	_ = d.Set("external_network_id", router.CCGatewayInfo.NetworkID)
-	_ = d.Set("external_port_id", router.CCGatewayInfo.ExternalPortID)
-	_ = d.Set("enable_snat", router.CCGatewayInfo.EnableSNAT)
-	_ = d.Set("all_tags", router.Tags)
-	_ = d.Set("region", GetRegion(d, config))
+// networkingRouterV2SNATEnabled returns the effective SNAT state of the
+// external gateway. Neutron enables SNAT by default, when the gateway doesn't
+// report it, and a router without a gateway has no SNAT.
+func networkingRouterV2SNATEnabled(gw GatewayInfo) bool {
+	if gw.NetworkID == "" {
+		return false
+	}
+	if gw.EnableSNAT == nil {
+		return true
+	}
+	return *gw.EnableSNAT
+}
 
-	if err := d.Set("availability_zone_hints", router.AvailabilityZoneHints); err != nil {
-		log.Printf("[DEBUG] Unable to set availability_zone_hints: %s", err)
+// flattenNetworkingRouterV2Interfaces returns an interface for each fixed IP
+// of the internal router ports, the external gateway port is skipped.
+func flattenNetworkingRouterV2Interfaces(allPorts []ports.Port) []map[string]string {
+	interfaces := make([]map[string]string, 0, len(allPorts))
+	for _, port := range allPorts {
+		switch port.DeviceOwner {
+		case "network:router_interface", "network:router_interface_distributed", "network:ha_router_replicated_interface":
+		default:
+			continue
+		}
+		for _, ip := range port.FixedIPs {
+			interfaces = append(interfaces, map[string]string{
+				"port_id":    port.ID,
+				"subnet_id":  ip.SubnetID,
+				"ip_address": ip.IPAddress,
+			})
+		}
 	}
+	return interfaces
+}
 
-	externalFixedIPs := make([]map[string]string, 0, len(router.GatewayInfo.ExternalFixedIPs))
-	for _, v := range router.GatewayInfo.ExternalFixedIPs {
+func flattenNetworkingRouterV2ExternalFixedIPs(ips []routers.ExternalFixedIP) []map[string]string {
+	externalFixedIPs := make([]map[string]string, 0, len(ips))
+	for _, v := range ips {
 		externalFixedIPs = append(externalFixedIPs, map[string]string{
 			"subnet_id":  v.SubnetID,
 			"ip_address": v.IPAddress,
 		})
 	}
-	if err = d.Set("external_fixed_ip", externalFixedIPs); err != nil {
-		log.Printf("[DEBUG] Unable to set external_fixed_ip: %s", err)
+	return externalFixedIPs
+}
+
+func flattenNetworkingRouterV2Routes(routes []routers.Route) []map[string]string {
+	res := make([]map[string]string, 0, len(routes))
+	for _, v := range routes {
+		res = append(res, map[string]string{
+			"destination_cidr": v.DestinationCIDR,
+			"next_hop":         v.NextHop,
+		})
 	}
-	return nil
+	return res
+}
+
+func flattenNetworkingRouterV2ConntrackHelpers(helpers []ConntrackHelper) []map[string]any {
+	res := make([]map[string]any, 0, len(helpers))
+	for _, v := range helpers {
+		res = append(res, map[string]any{
+			"helper":   v.Helper,
+			"protocol": v.Protocol,
+			"port":     v.Port,
+		})
+	}
+	return res
 }
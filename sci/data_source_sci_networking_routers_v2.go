@@ -0,0 +1,221 @@
+package sci
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/layer3/routers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSCINetworkingRoutersV2() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSCINetworkingRoutersV2Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"router_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"admin_state_up": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"distributed": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"tags_any": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"not_tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"not_tags_any": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			// computed
+			"routers": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"admin_state_up": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"distributed": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tenant_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"external_network_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"external_port_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enable_snat": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"snat_enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"qos_policy_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"availability_zone_hints": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"external_fixed_ip": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"subnet_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"ip_address": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"all_tags": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSCINetworkingRoutersV2Read(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	config := meta.(*Config)
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
+	}
+
+	listOpts := networkingRouterV2ListOpts(d)
+
+	pages, err := routers.List(networkingClient, listOpts).AllPages(ctx)
+	if err != nil {
+		return diag.Errorf("Unable to list Routers: %s", err)
+	}
+
+	var allRouters []ccRouter
+	err = routers.ExtractRoutersInto(pages, &allRouters)
+	if err != nil {
+		return diag.Errorf("Unable to retrieve Routers: %s", err)
+	}
+
+	log.Printf("[DEBUG] Retrieved %d Routers: %+v", len(allRouters), allRouters)
+
+	d.SetId(networkingRoutersV2Hash(allRouters))
+	if err = d.Set("routers", flattenNetworkingRoutersV2(allRouters)); err != nil {
+		return diag.Errorf("Unable to set routers: %s", err)
+	}
+	_ = d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func flattenNetworkingRoutersV2(allRouters []ccRouter) []map[string]any {
+	res := make([]map[string]any, len(allRouters))
+	for i, router := range allRouters {
+		res[i] = map[string]any{
+			"id":                      router.ID,
+			"name":                    router.Name,
+			"description":             router.Description,
+			"admin_state_up":          router.AdminStateUp,
+			"distributed":             router.Distributed,
+			"status":                  router.Status,
+			"tenant_id":               router.TenantID,
+			"external_network_id":     router.CCGatewayInfo.NetworkID,
+			"external_port_id":        router.CCGatewayInfo.ExternalPortID,
+			"enable_snat":             ptrValue(router.CCGatewayInfo.EnableSNAT),
+			"snat_enabled":            networkingRouterV2SNATEnabled(router.CCGatewayInfo),
+			"qos_policy_id":           router.CCGatewayInfo.QoSPolicyID,
+			"availability_zone_hints": router.AvailabilityZoneHints,
+			"external_fixed_ip":       flattenNetworkingRouterV2ExternalFixedIPs(router.CCGatewayInfo.ExternalFixedIPs),
+			"all_tags":                router.Tags,
+		}
+	}
+	return res
+}
+
+func networkingRoutersV2Hash(allRouters []ccRouter) string {
+	h := sha256.New()
+	for _, router := range allRouters {
+		h.Write([]byte(router.ID))
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
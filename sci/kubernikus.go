@@ -10,13 +10,14 @@ import (
 	httptransport "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
 	"github.com/gophercloud/gophercloud/v2"
-	osClient "github.com/gophercloud/utils/v2/client"
 	"github.com/sapcc/kubernikus/pkg/api/client/operations"
 )
 
 type kubernikus struct {
 	operations.ClientService
-	provider *gophercloud.ProviderClient
+	provider  *gophercloud.ProviderClient
+	transport *httptransport.Runtime
+	endpoint  string
 }
 
 func newKubernikusV1(c *Config, eo gophercloud.EndpointOpts) (*kubernikus, error) {
@@ -34,7 +35,7 @@ func newKubernikusV1(c *Config, eo gophercloud.EndpointOpts) (*kubernikus, error
 		eo.ApplyDefaults("kubernikus")
 		endpoint, err = c.OsClient.EndpointLocator(eo)
 		if err != nil {
-			return nil, err
+			return nil, endpointLocatorError(c.OsClient, err, eo)
 		}
 	}
 
@@ -42,17 +43,11 @@ func newKubernikusV1(c *Config, eo gophercloud.EndpointOpts) (*kubernikus, error
 		return nil, fmt.Errorf("parsing the Kubernikus URL failed: %s", err)
 	}
 
-	transport := httptransport.New(kurl.Host, kurl.EscapedPath(), []string{kurl.Scheme})
-
-	if v, ok := c.OsClient.HTTPClient.Transport.(*osClient.RoundTripper); ok && v.Logger != nil {
-		// enable JSON debug for Kubernikus
-		transport.SetLogger(logger{"Kubernikus"})
-		transport.Debug = true
-	}
+	transport := newOpenAPITransport(c, kurl, "kubernikus", "Kubernikus")
 
 	operations := operations.New(transport, strfmt.Default)
 
-	return &kubernikus{operations, c.OsClient}, nil
+	return &kubernikus{operations, c.OsClient, transport, endpoint}, nil
 }
 
 func (k *kubernikus) authFunc() runtime.ClientAuthInfoWriterFunc {
@@ -69,3 +64,8 @@ func (k *kubernikus) authFunc() runtime.ClientAuthInfoWriterFunc {
 			return nil
 		})
 }
+
+// lastRequestID returns the request ID of the last Kubernikus API response.
+func (k *kubernikus) lastRequestID() string {
+	return transportLastRequestID(k.transport)
+}
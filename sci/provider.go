@@ -2,15 +2,25 @@ package sci
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/url"
 	"os"
 	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack"
+	osClient "github.com/gophercloud/utils/v2/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/terraform-provider-openstack/utils/v2/auth"
 	"github.com/terraform-provider-openstack/utils/v2/mutexkv"
+	"golang.org/x/net/http/httpproxy"
 )
 
 var version = "dev"
@@ -19,6 +29,30 @@ var version = "dev"
 // Config struct.
 type Config struct {
 	auth.Config
+
+	// RetryBaseDelay and RetryMaxDelay define the exponential backoff used
+	// to retry requests to the SAP specific services.
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+
+	// InsecureServices lists the services, for which the TLS verification
+	// is disabled.
+	InsecureServices []string
+
+	// ProxyConfig defines the HTTP proxies used by all API clients.
+	ProxyConfig *httpproxy.Config
+
+	// KubernikusAPIVersion is the Kubernikus API version, which must be
+	// served by every Kubernikus endpoint. The verified endpoints are cached
+	// in kubernikusVerified.
+	KubernikusAPIVersion string
+	kubernikusVerified   sync.Map
+
+	// KubernikusAdminRole is the role required by the Kubernikus admin API.
+	// The verification results are cached per region and token in
+	// kubernikusAdminVerified.
+	KubernikusAdminRole     string
+	kubernikusAdminVerified sync.Map
 }
 
 // Provider returns a schema.Provider for OpenStack.
@@ -175,6 +209,32 @@ func Provider() *schema.Provider {
 				Description: descriptions["insecure"],
 			},
 
+			"insecure_services": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{
+						"endpoint-services", "gtm", "kubernikus",
+					}, false),
+				},
+				Description: descriptions["insecure_services"],
+			},
+
+			"kubernikus_api_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"v1"}, false),
+				Description:  descriptions["kubernikus_api_version"],
+			},
+
+			"kubernikus_admin_role": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "kubernetes_admin",
+				Description: descriptions["kubernikus_admin_role"],
+			},
+
 			"endpoint_type": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -230,6 +290,43 @@ func Provider() *schema.Provider {
 				Description: descriptions["max_retries"],
 			},
 
+			"retry_base_delay": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultRetryBaseDelay.String(),
+				ValidateFunc: validateDuration,
+				Description:  descriptions["retry_base_delay"],
+			},
+
+			"retry_max_delay": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultRetryMaxDelay.String(),
+				ValidateFunc: validateDuration,
+				Description:  descriptions["retry_max_delay"],
+			},
+
+			"http_proxy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"HTTP_PROXY", "http_proxy"}, ""),
+				Description: descriptions["http_proxy"],
+			},
+
+			"https_proxy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"HTTPS_PROXY", "https_proxy"}, ""),
+				Description: descriptions["https_proxy"],
+			},
+
+			"no_proxy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"NO_PROXY", "no_proxy"}, ""),
+				Description: descriptions["no_proxy"],
+			},
+
 			"endpoint_overrides": {
 				Type:        schema.TypeMap,
 				Optional:    true,
@@ -243,6 +340,12 @@ func Provider() *schema.Provider {
 				Description: descriptions["disable_no_cache_header"],
 			},
 
+			"user_agent_extra": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: descriptions["user_agent_extra"],
+			},
+
 			"enable_logging": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -252,11 +355,17 @@ func Provider() *schema.Provider {
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"sci_billing_domain_masterdata":  dataSourceSCIBillingDomainMasterdata(),
-			"sci_billing_project_masterdata": dataSourceSCIBillingProjectMasterdata(),
-			"sci_gslb_services_v1":           dataSourceSCIGSLBServicesV1(),
-			"sci_endpoint_service_v1":        dataSourceSCIEndpointServiceV1(),
-			"sci_networking_router_v2":       dataSourceSCINetworkingRouterV2(),
+			"sci_auth_scope_v1":               dataSourceSCIAuthScopeV1(),
+			"sci_billing_domain_masterdata":   dataSourceSCIBillingDomainMasterdata(),
+			"sci_billing_incomplete_projects": dataSourceSCIBillingIncompleteProjects(),
+			"sci_bgpvpn_interconnection_v2":   dataSourceSCIBGPVPNInterconnectionV2(),
+			"sci_billing_project_masterdata":  dataSourceSCIBillingProjectMasterdata(),
+			"sci_gslb_services_v1":            dataSourceSCIGSLBServicesV1(),
+			"sci_gslb_quotas_v1":              dataSourceSCIGSLBQuotasV1(),
+			"sci_endpoint_service_v1":         dataSourceSCIEndpointServiceV1(),
+			"sci_networking_router_v2":        dataSourceSCINetworkingRouterV2(),
+			"sci_networking_routers_v2":       dataSourceSCINetworkingRoutersV2(),
+			"sci_kubernetes_clusters_v1":      dataSourceSCIKubernetesClustersV1(),
 			// old provider names
 			"ccloud_billing_domain_masterdata":  dataSourceSCIBillingDomainMasterdata(),
 			"ccloud_billing_project_masterdata": dataSourceSCIBillingProjectMasterdata(),
@@ -282,6 +391,8 @@ func Provider() *schema.Provider {
 			"sci_endpoint_accept_v1":         resourceSCIEndpointAcceptV1(),
 			"sci_endpoint_quota_v1":          resourceSCIEndpointQuotaV1(),
 			"sci_endpoint_rbac_policy_v1":    resourceSCIEndpointRBACV1(),
+			"sci_endpoint_rbac_policies_v1":  resourceSCIEndpointRBACPoliciesV1(),
+			"sci_networking_router_v2":       resourceSCINetworkingRouterV2(),
 			// old provider names
 			"ccloud_billing_domain_masterdata":  resourceSCIBillingDomainMasterdata(),
 			"ccloud_billing_project_masterdata": resourceSCIBillingProjectMasterdata(),
@@ -316,6 +427,17 @@ func Provider() *schema.Provider {
 	return provider
 }
 
+// endpointOverrideServices are the service types, which are used by this
+// provider and can be overridden using the endpoint_overrides argument.
+var endpointOverrideServices = []string{
+	"endpoint-services",
+	"gtm",
+	"identity",
+	"kubernikus",
+	"network",
+	"sapcc-billing",
+}
+
 var descriptions map[string]string
 
 func init() {
@@ -364,6 +486,12 @@ func init() {
 
 		"insecure": "Trust self-signed certificates.",
 
+		"insecure_services": "A list of services, for which self-signed certificates are trusted.",
+
+		"kubernikus_api_version": "The Kubernikus API version, which must be served by the Kubernikus endpoints.",
+
+		"kubernikus_admin_role": "The role required by the Kubernikus admin API. An empty string disables the check.",
+
 		"cacert_file": "A Custom CA certificate.",
 
 		"cert": "A client certificate to authenticate with.",
@@ -385,10 +513,37 @@ func init() {
 
 		"max_retries": "How many times HTTP connection should be retried until giving up.",
 
+		"retry_base_delay": "The initial delay between retries of requests to the SAP specific services,\n" +
+			"which is doubled after every retry. Defaults to `1s`.",
+
+		"retry_max_delay": "The maximum delay between retries of requests to the SAP specific services.\n" +
+			"Defaults to `30s`.",
+
+		"http_proxy": "The proxy to use for HTTP requests.",
+
+		"https_proxy": "The proxy to use for HTTPS requests.",
+
+		"no_proxy": "A comma-separated list of hosts which should be accessed without a proxy.",
+
+		"user_agent_extra": "A string appended to the User-Agent header of all API requests.",
+
 		"enable_logging": "Outputs very verbose logs with all calls made to and responses from OpenStack",
 	}
 }
 
+// proxyFunc returns the proxy function for the HTTP transports. The
+// environment variables are respected, when no proxy was configured.
+func (c *Config) proxyFunc() func(*http.Request) (*url.URL, error) {
+	if c.ProxyConfig == nil {
+		return http.ProxyFromEnvironment
+	}
+
+	proxyFunc := c.ProxyConfig.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+}
+
 func getSDKVersion() string {
 	buildInfo, ok := debug.ReadBuildInfo()
 	if !ok {
@@ -404,7 +559,35 @@ func getSDKVersion() string {
 	return ""
 }
 
+// validateEndpointOverrides verifies that all endpoint overrides are well
+// formed URLs and warns about services, which are not used by this provider.
+func validateEndpointOverrides(overrides map[string]any) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for service, v := range overrides {
+		if !sliceContains(endpointOverrideServices, service) {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Unknown endpoint override %q", service),
+				Detail:   fmt.Sprintf("The %q service is not used by this provider, supported services are: %s", service, strings.Join(endpointOverrideServices, ", ")),
+			})
+		}
+
+		_, errs := validateURL(v, fmt.Sprintf("endpoint_overrides.%s", service))
+		for _, err := range errs {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	return diags
+}
+
 func configureProvider(ctx context.Context, d *schema.ResourceData, terraformVersion string) (any, diag.Diagnostics) {
+	diags := validateEndpointOverrides(d.Get("endpoint_overrides").(map[string]any))
+	if diags.HasError() {
+		return nil, diags
+	}
+
 	enableLogging := d.Get("enable_logging").(bool)
 	if !enableLogging {
 		// enforce logging (similar to OS_DEBUG) when TF_LOG is 'DEBUG' or 'TRACE'
@@ -415,12 +598,18 @@ func configureProvider(ctx context.Context, d *schema.ResourceData, terraformVer
 		}
 	}
 
+	// the SDK version is a part of the User-Agent, which is used by all clients
+	sdkVersion := getSDKVersion() + " Terraform Provider SCI/" + version
+	if v := strings.TrimSpace(d.Get("user_agent_extra").(string)); v != "" {
+		sdkVersion += " " + v
+	}
+
 	authOpts := &gophercloud.AuthOptions{
 		Scope: &gophercloud.AuthScope{System: d.Get("system_scope").(bool)},
 	}
 
 	config := Config{
-		auth.Config{
+		Config: auth.Config{
 			CACertFile:                  d.Get("cacert_file").(string),
 			ClientCertFile:              d.Get("cert").(string),
 			ClientKeyFile:               d.Get("key").(string),
@@ -451,21 +640,53 @@ func configureProvider(ctx context.Context, d *schema.ResourceData, terraformVer
 			MaxRetries:                  d.Get("max_retries").(int),
 			DisableNoCacheHeader:        d.Get("disable_no_cache_header").(bool),
 			TerraformVersion:            terraformVersion,
-			SDKVersion:                  getSDKVersion() + " Terraform Provider SCI/" + version,
+			SDKVersion:                  sdkVersion,
 			MutexKV:                     mutexkv.NewMutexKV(),
 			EnableLogger:                enableLogging,
 		},
 	}
 
+	// the durations have already been validated by the schema
+	config.RetryBaseDelay, _ = time.ParseDuration(d.Get("retry_base_delay").(string))
+	config.RetryMaxDelay, _ = time.ParseDuration(d.Get("retry_max_delay").(string))
+
+	config.InsecureServices = expandToStringSlice(d.Get("insecure_services").(*schema.Set).List())
+	config.KubernikusAPIVersion = d.Get("kubernikus_api_version").(string)
+	config.KubernikusAdminRole = d.Get("kubernikus_admin_role").(string)
+
+	config.ProxyConfig = &httpproxy.Config{
+		HTTPProxy:  d.Get("http_proxy").(string),
+		HTTPSProxy: d.Get("https_proxy").(string),
+		NoProxy:    d.Get("no_proxy").(string),
+	}
+
 	v, ok := getOkExists(d, "insecure")
 	if ok {
 		insecure := v.(bool)
 		config.Insecure = &insecure
 	}
 
+	// LoadAndValidate authenticates with a transport, which ignores the
+	// proxy configuration. Delay the authentication until the proxy is
+	// applied to the gophercloud clients.
+	delayedAuth := config.DelayedAuth
+	config.DelayedAuth = true
 	if err := config.LoadAndValidate(ctx); err != nil {
-		return nil, diag.FromErr(err)
+		return nil, append(diags, diag.FromErr(err)...)
+	}
+	config.DelayedAuth = delayedAuth
+
+	if v, ok := config.OsClient.HTTPClient.Transport.(*osClient.RoundTripper); ok {
+		if t, ok := v.Rt.(*http.Transport); ok {
+			t.Proxy = config.proxyFunc()
+		}
+	}
+
+	if !config.DelayedAuth && !config.Swauth {
+		if err := openstack.Authenticate(ctx, config.OsClient, *config.AuthOpts); err != nil {
+			return nil, append(diags, diag.FromErr(err)...)
+		}
 	}
 
-	return &config, nil
+	return &config, diags
 }
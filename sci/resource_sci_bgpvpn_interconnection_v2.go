@@ -2,10 +2,14 @@ package sci
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strings"
 	"time"
 
+	"github.com/gophercloud/gophercloud/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/sapcc/gophercloud-sapcc/v2/networking/v2/bgpvpn/interconnections"
@@ -18,7 +22,7 @@ func resourceSCIBGPVPNInterconnectionV2() *schema.Resource {
 		UpdateContext: resourceSCIBGPVPNInterconnectionV2Update,
 		DeleteContext: resourceSCIBGPVPNInterconnectionV2Delete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceSCIBGPVPNInterconnectionV2Import,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -136,6 +140,12 @@ func resourceSCIBGPVPNInterconnectionV2Create(ctx context.Context, d *schema.Res
 
 	d.SetId(interConn.ID)
 
+	timeout := d.Timeout(schema.TimeoutCreate)
+	err = bgpvpnInterconnectionV2WaitForState(ctx, networkingClient, d.Id(), createOpts.RemoteInterconnectionID != "", timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	return resourceSCIBGPVPNInterconnectionV2Read(ctx, d, meta)
 }
 
@@ -206,6 +216,15 @@ func resourceSCIBGPVPNInterconnectionV2Update(ctx context.Context, d *schema.Res
 		}
 
 		log.Printf("[DEBUG] Updated BGP VPN interconnection with id %s", d.Id())
+
+		if d.HasChange("remote_interconnection_id") {
+			hasRemote := d.Get("remote_interconnection_id").(string) != ""
+			timeout := d.Timeout(schema.TimeoutUpdate)
+			err = bgpvpnInterconnectionV2WaitForState(ctx, networkingClient, d.Id(), hasRemote, timeout)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
 	}
 
 	return resourceSCIBGPVPNInterconnectionV2Read(ctx, d, meta)
@@ -227,3 +246,80 @@ func resourceSCIBGPVPNInterconnectionV2Delete(ctx context.Context, d *schema.Res
 
 	return nil
 }
+
+func resourceSCIBGPVPNInterconnectionV2Import(ctx context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	var id, region string
+	switch len(parts) {
+	case 1:
+		id = parts[0]
+	case 2:
+		id, region = parts[0], parts[1]
+	}
+
+	if id == "" || (len(parts) == 2 && region == "") {
+		return nil, fmt.Errorf("invalid format specified for BGP VPN interconnection, format must be <id>[/<region>]")
+	}
+
+	d.SetId(id)
+	if region != "" {
+		_ = d.Set("region", region)
+	}
+
+	if diags := resourceSCIBGPVPNInterconnectionV2Read(ctx, d, meta); diags.HasError() {
+		return nil, fmt.Errorf("error importing BGP VPN interconnection %s: %s", id, diags[0].Summary)
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("BGP VPN interconnection %s not found", id)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// bgpvpnInterconnectionV2WaitForState waits until the interconnection has
+// been validated by its peer. When the remote interconnection is not known
+// yet, the WAITING_REMOTE state is accepted as well, since the peer side can
+// only be created afterwards.
+func bgpvpnInterconnectionV2WaitForState(ctx context.Context, client *gophercloud.ServiceClient, id string, hasRemote bool, timeout time.Duration) error {
+	target := []string{"ACTIVE", "VALIDATED"}
+	pending := []string{"VALIDATING"}
+	if hasRemote {
+		pending = append(pending, "WAITING_REMOTE")
+	} else {
+		target = append(target, "WAITING_REMOTE")
+	}
+
+	log.Printf("[DEBUG] Waiting for BGP VPN interconnection %s to become %v", id, target)
+
+	stateConf := &retry.StateChangeConf{
+		Target:     target,
+		Pending:    pending,
+		Refresh:    bgpvpnInterconnectionV2GetState(ctx, client, id),
+		Timeout:    timeout,
+		Delay:      1 * time.Second,
+		MinTimeout: 2 * time.Second,
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error waiting for BGP VPN interconnection %s to become %v: %s", id, target, err)
+	}
+
+	return nil
+}
+
+func bgpvpnInterconnectionV2GetState(ctx context.Context, client *gophercloud.ServiceClient, id string) retry.StateRefreshFunc {
+	return func() (any, string, error) {
+		interConn, err := interconnections.Get(ctx, client, id).Extract()
+		if err != nil {
+			return nil, "", err
+		}
+
+		if interConn.State == "TEARDOWN" {
+			return interConn, interConn.State, fmt.Errorf("the interconnection was rejected by the remote side (state %s, remote region %s, remote interconnection %q)",
+				interConn.State, interConn.RemoteRegion, interConn.RemoteInterconnectionID)
+		}
+
+		return interConn, interConn.State, nil
+	}
+}
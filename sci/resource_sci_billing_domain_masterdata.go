@@ -23,6 +23,8 @@ func resourceSCIBillingDomainMasterdata() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: billingValidateCostObject,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -23,6 +23,8 @@ func resourceSCIBillingProjectMasterdata() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: billingValidateCostObject,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
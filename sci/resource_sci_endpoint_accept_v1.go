@@ -23,6 +23,11 @@ func resourceSCIEndpointAcceptV1() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -72,7 +77,7 @@ func resourceSCIEndpointAcceptV1Create(ctx context.Context, d *schema.ResourceDa
 	}
 	res, err := client.PutServiceServiceIDAcceptEndpoints(opts, c.authFunc())
 	if err != nil {
-		return diag.Errorf("error accepting Archer endpoint: %s", err)
+		return diag.Errorf("error accepting Archer endpoint: %s", archerErrorMessage(c, err))
 	}
 	if res == nil || res.Payload == nil {
 		return diag.Errorf("error accepting Archer endpoint: empty response")
@@ -91,6 +96,7 @@ func resourceSCIEndpointAcceptV1Create(ctx context.Context, d *schema.ResourceDa
 	pending := []string{
 		string(models.EndpointStatusPENDINGCREATE),
 		string(models.EndpointStatusPENDINGAPPROVAL),
+		string(models.EndpointStatusPENDINGUPDATE),
 	}
 	ec, err := archerWaitForServiceEndpointConsumer(ctx, c, endpointID, serviceID, target, pending, timeout)
 	if err != nil {
@@ -116,11 +122,11 @@ func resourceSCIEndpointAcceptV1Read(ctx context.Context, d *schema.ResourceData
 
 	ec, err := archerGetServiceEndpointConsumer(ctx, c, id, serviceID)
 	if err != nil {
-		if _, ok := err.(*service.GetServiceServiceIDEndpointsNotFound); ok {
+		if archerIsNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return diag.Errorf("error reading Archer endpoint consumer: %s", err)
+		return diag.Errorf("error reading Archer endpoint consumer: %s", archerErrorMessage(c, err))
 	}
 
 	archerSetServiceEndpointConsumer(d, config, id, ec)
@@ -151,10 +157,10 @@ func resourceSCIEndpointAcceptV1Delete(ctx context.Context, d *schema.ResourceDa
 	}
 	_, err = client.PutServiceServiceIDRejectEndpoints(opts, c.authFunc())
 	if err != nil {
-		if _, ok := err.(*service.PutServiceServiceIDRejectEndpointsNotFound); ok {
+		if archerIsNotFound(err) {
 			return nil
 		}
-		return diag.Errorf("error rejecting Archer endpoint: %s", err)
+		return diag.Errorf("error rejecting Archer endpoint: %s", archerErrorMessage(c, err))
 	}
 
 	// waiting for DELETED status
@@ -189,10 +195,10 @@ func archerWaitForServiceEndpointConsumer(ctx context.Context, c *archer, id, se
 
 	ec, err := stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		if _, ok := err.(*service.GetServiceServiceIDEndpointsNotFound); ok && sliceContains(target, "DELETED") {
+		if archerIsNotFound(err) && sliceContains(target, "DELETED") {
 			return nil, nil
 		}
-		return nil, fmt.Errorf("error waiting for %s endpoint to become %s: %s", id, target, err)
+		return nil, fmt.Errorf("error waiting for %s endpoint to become %s: %s", id, target, archerErrorMessage(c, err))
 	}
 
 	return ec.(*models.EndpointConsumer), nil
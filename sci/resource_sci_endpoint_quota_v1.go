@@ -2,7 +2,9 @@ package sci
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -17,7 +19,7 @@ func resourceSCIEndpointQuotaV1() *schema.Resource {
 		UpdateContext: resourceSCIEndpointQuotaV1Update,
 		DeleteContext: resourceSCIEndpointQuotaV1Delete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceSCIEndpointQuotaV1Import,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -53,6 +55,14 @@ func resourceSCIEndpointQuotaV1() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"available_endpoint": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"available_service": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -81,7 +91,7 @@ func resourceSCIEndpointQuotaV1Create(ctx context.Context, d *schema.ResourceDat
 	}
 	res, err := client.PutQuotasProjectID(opts, c.authFunc())
 	if err != nil {
-		return diag.Errorf("error creating Archer quota: %s", err)
+		return diag.Errorf("error creating Archer quota: %s", archerErrorMessage(c, err))
 	}
 	if res == nil || res.Payload == nil {
 		return diag.Errorf("error creating Archer quota: empty response")
@@ -109,17 +119,14 @@ func resourceSCIEndpointQuotaV1Read(ctx context.Context, d *schema.ResourceData,
 	}
 	res, err := client.GetQuotasProjectID(opts, c.authFunc())
 	if err != nil {
-		return diag.Errorf("error reading Archer quota: %s, %T", err, err)
-	}
-	if res == nil || res.Payload == nil {
-		return diag.Errorf("error reading Archer quota: empty response")
-	}
-	if err != nil {
-		if _, ok := err.(*quota.GetQuotasNotFound); ok {
+		if archerIsNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diag.Errorf("error reading Archer quota: %s", archerErrorMessage(c, err))
+	}
+	if res == nil || res.Payload == nil {
+		return diag.Errorf("error reading Archer quota: empty response")
 	}
 
 	archerSetQuotaResource(d, config, res.Payload)
@@ -135,10 +142,15 @@ func resourceSCIEndpointQuotaV1Update(ctx context.Context, d *schema.ResourceDat
 	}
 	client := c.Quota
 
+	// send only the changed limits, so that the values set out-of-band are
+	// not overwritten with a stale state
 	id := d.Id()
-	req := &models.Quota{
-		Endpoint: int64(d.Get("endpoint").(int)),
-		Service:  int64(d.Get("service").(int)),
+	req := &models.Quota{}
+	if d.HasChange("endpoint") {
+		req.Endpoint = int64(d.Get("endpoint").(int))
+	}
+	if d.HasChange("service") {
+		req.Service = int64(d.Get("service").(int))
 	}
 
 	opts := &quota.PutQuotasProjectIDParams{
@@ -148,7 +160,7 @@ func resourceSCIEndpointQuotaV1Update(ctx context.Context, d *schema.ResourceDat
 	}
 	res, err := client.PutQuotasProjectID(opts, c.authFunc())
 	if err != nil {
-		return diag.Errorf("error updating Archer quota: %s", err)
+		return diag.Errorf("error updating Archer quota: %s", archerErrorMessage(c, err))
 	}
 	if res == nil || res.Payload == nil {
 		return diag.Errorf("error updating Archer quota: empty response")
@@ -172,15 +184,45 @@ func resourceSCIEndpointQuotaV1Delete(ctx context.Context, d *schema.ResourceDat
 	}
 	_, err = client.DeleteQuotasProjectID(opts, c.authFunc())
 	if err != nil {
-		if _, ok := err.(*quota.DeleteQuotasProjectIDNotFound); ok {
+		if archerIsNotFound(err) {
 			return nil
 		}
-		return diag.Errorf("error deleting Archer quota: %s", err)
+		return diag.Errorf("error deleting Archer quota: %s", archerErrorMessage(c, err))
 	}
 
 	return nil
 }
 
+func resourceSCIEndpointQuotaV1Import(ctx context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	var region, projectID string
+	switch len(parts) {
+	case 1:
+		projectID = parts[0]
+	case 2:
+		region, projectID = parts[0], parts[1]
+	}
+
+	if projectID == "" || (len(parts) == 2 && region == "") {
+		return nil, fmt.Errorf("invalid format specified for Archer quota, format must be [<region>/]<project_id>")
+	}
+
+	d.SetId(projectID)
+	_ = d.Set("project_id", projectID)
+	if region != "" {
+		_ = d.Set("region", region)
+	}
+
+	if diags := resourceSCIEndpointQuotaV1Read(ctx, d, meta); diags.HasError() {
+		return nil, fmt.Errorf("error importing Archer quota %s: %s", projectID, diags[0].Summary)
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("the Archer quota of project %s was not found", projectID)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func archerSetQuotaResource(d *schema.ResourceData, config *Config, q *quota.GetQuotasProjectIDOKBody) {
 	_ = d.Set("endpoint", q.Endpoint)
 	_ = d.Set("service", q.Service)
@@ -188,6 +230,17 @@ func archerSetQuotaResource(d *schema.ResourceData, config *Config, q *quota.Get
 	// computed
 	_ = d.Set("in_use_endpoint", q.InUseEndpoint)
 	_ = d.Set("in_use_service", q.InUseService)
+	_ = d.Set("available_endpoint", archerQuotaAvailable(q.Endpoint, q.InUseEndpoint))
+	_ = d.Set("available_service", archerQuotaAvailable(q.Service, q.InUseService))
 
 	_ = d.Set("region", GetRegion(d, config))
 }
+
+// archerQuotaAvailable returns the remaining quota, or -1 for an unlimited
+// quota.
+func archerQuotaAvailable(limit, inUse int64) int64 {
+	if limit < 0 {
+		return -1
+	}
+	return max(limit-inUse, 0)
+}
@@ -0,0 +1,299 @@
+package sci
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sapcc/archer/client/rbac"
+	"github.com/sapcc/archer/models"
+)
+
+func resourceSCIEndpointRBACPoliciesV1() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSCIEndpointRBACPoliciesV1Create,
+		ReadContext:   resourceSCIEndpointRBACPoliciesV1Read,
+		UpdateContext: resourceSCIEndpointRBACPoliciesV1Update,
+		DeleteContext: resourceSCIEndpointRBACPoliciesV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"service_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"targets": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+			},
+			"target_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"project",
+				}, false),
+			},
+
+			// computed
+			"policies": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceSCIEndpointRBACPoliciesV1Create(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	config := meta.(*Config)
+	c, err := config.archerV1Client(ctx, GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("error creating Archer client: %s", err)
+	}
+
+	d.SetId(d.Get("service_id").(string))
+
+	policies := make(map[string]string)
+	targets := expandToStringSlice(d.Get("targets").(*schema.Set).List())
+	err = archerCreateRBACPolicies(ctx, c, d, targets, policies)
+	_ = d.Set("policies", policies)
+	if err != nil {
+		if len(policies) == 0 {
+			d.SetId("")
+		}
+		return diag.FromErr(err)
+	}
+
+	return resourceSCIEndpointRBACPoliciesV1Read(ctx, d, meta)
+}
+
+func resourceSCIEndpointRBACPoliciesV1Read(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	config := meta.(*Config)
+	c, err := config.archerV1Client(ctx, GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("error creating Archer client: %s", err)
+	}
+	client := c.Rbac
+
+	known := d.Get("policies").(map[string]any)
+	if len(known) == 0 {
+		// imported, discover the policies of the service
+		all, err := archerListRBACPolicies(ctx, c)
+		if err != nil {
+			return diag.Errorf("error listing Archer RBAC policies: %s", archerErrorMessage(c, err))
+		}
+		for _, p := range all {
+			if p != nil && ptrValue(p.ServiceID) == strfmt.UUID(d.Id()) {
+				known[p.Target] = string(p.ID)
+			}
+		}
+	}
+
+	policies := make(map[string]string)
+	var targets []string
+	for target, id := range known {
+		opts := &rbac.GetRbacPoliciesRbacPolicyIDParams{
+			RbacPolicyID: strfmt.UUID(id.(string)),
+			Context:      ctx,
+		}
+		res, err := client.GetRbacPoliciesRbacPolicyID(opts, c.authFunc())
+		if err != nil {
+			if archerIsNotFound(err) {
+				log.Printf("[DEBUG] Archer RBAC policy %s for target %s not found", id, target)
+				continue
+			}
+			return diag.Errorf("error reading Archer RBAC policy %s: %s", id, archerErrorMessage(c, err))
+		}
+		if res == nil || res.Payload == nil {
+			return diag.Errorf("error reading Archer RBAC policy %s: empty response", id)
+		}
+
+		log.Printf("[DEBUG] Read Archer RBAC policy: %v", res)
+
+		policies[res.Payload.Target] = string(res.Payload.ID)
+		targets = append(targets, res.Payload.Target)
+		_ = d.Set("project_id", res.Payload.ProjectID)
+		_ = d.Set("target_type", ptrValue(res.Payload.TargetType))
+	}
+
+	if len(policies) == 0 {
+		log.Printf("[DEBUG] No Archer RBAC policies found for service %s", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	_ = d.Set("service_id", d.Id())
+	_ = d.Set("targets", targets)
+	_ = d.Set("policies", policies)
+	_ = d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceSCIEndpointRBACPoliciesV1Update(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	config := meta.(*Config)
+	c, err := config.archerV1Client(ctx, GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("error creating Archer client: %s", err)
+	}
+
+	policies := make(map[string]string)
+	for target, id := range d.Get("policies").(map[string]any) {
+		policies[target] = id.(string)
+	}
+
+	if d.HasChange("targets") {
+		o, n := d.GetChange("targets")
+		oldTargets, newTargets := o.(*schema.Set), n.(*schema.Set)
+
+		removed := expandToStringSlice(oldTargets.Difference(newTargets).List())
+		err = archerDeleteRBACPolicies(ctx, c, removed, policies)
+		if err == nil {
+			added := expandToStringSlice(newTargets.Difference(oldTargets).List())
+			err = archerCreateRBACPolicies(ctx, c, d, added, policies)
+		}
+		_ = d.Set("policies", policies)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceSCIEndpointRBACPoliciesV1Read(ctx, d, meta)
+}
+
+func resourceSCIEndpointRBACPoliciesV1Delete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	config := meta.(*Config)
+	c, err := config.archerV1Client(ctx, GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("error creating Archer client: %s", err)
+	}
+
+	policies := make(map[string]string)
+	var targets []string
+	for target, id := range d.Get("policies").(map[string]any) {
+		policies[target] = id.(string)
+		targets = append(targets, target)
+	}
+
+	err = archerDeleteRBACPolicies(ctx, c, targets, policies)
+	_ = d.Set("policies", policies)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// archerCreateRBACPolicies creates a policy for each of the targets and
+// records the created policy IDs in the policies map.
+func archerCreateRBACPolicies(ctx context.Context, c *archer, d *schema.ResourceData, targets []string, policies map[string]string) error {
+	for _, target := range targets {
+		req := &models.Rbacpolicy{
+			ProjectID: models.Project(d.Get("project_id").(string)),
+			ServiceID: ptr(strfmt.UUID(d.Get("service_id").(string))),
+			Target:    target,
+		}
+		if v, ok := d.GetOk("target_type"); ok {
+			req.TargetType = ptr(v.(string))
+		}
+
+		opts := &rbac.PostRbacPoliciesParams{
+			Body:    req,
+			Context: ctx,
+		}
+		res, err := c.Rbac.PostRbacPolicies(opts, c.authFunc())
+		if err != nil {
+			return fmt.Errorf("error creating Archer RBAC policy for target %s: %s", target, archerErrorMessage(c, err))
+		}
+		if res == nil || res.Payload == nil {
+			return fmt.Errorf("error creating Archer RBAC policy for target %s: empty response", target)
+		}
+
+		log.Printf("[DEBUG] Created Archer RBAC policy: %v", res)
+
+		policies[target] = string(res.Payload.ID)
+	}
+
+	return nil
+}
+
+// archerDeleteRBACPolicies deletes the policies of the targets and removes
+// them from the policies map.
+func archerDeleteRBACPolicies(ctx context.Context, c *archer, targets []string, policies map[string]string) error {
+	for _, target := range targets {
+		id, ok := policies[target]
+		if !ok {
+			continue
+		}
+
+		opts := &rbac.DeleteRbacPoliciesRbacPolicyIDParams{
+			RbacPolicyID: strfmt.UUID(id),
+			Context:      ctx,
+		}
+		_, err := c.Rbac.DeleteRbacPoliciesRbacPolicyID(opts, c.authFunc())
+		if err != nil {
+			if !archerIsNotFound(err) {
+				return fmt.Errorf("error deleting Archer RBAC policy %s for target %s: %s", id, target, archerErrorMessage(c, err))
+			}
+		}
+
+		delete(policies, target)
+	}
+
+	return nil
+}
+
+// archerListRBACPolicies returns the RBAC policies of all pages.
+func archerListRBACPolicies(ctx context.Context, c *archer) ([]*models.Rbacpolicy, error) {
+	var allPolicies []*models.Rbacpolicy
+	opts := &rbac.GetRbacPoliciesParams{
+		Context: ctx,
+	}
+	for {
+		res, err := c.Rbac.GetRbacPolicies(opts, c.authFunc())
+		if err != nil {
+			return nil, err
+		}
+		if res.Payload == nil || len(res.Payload.Items) == 0 {
+			return allPolicies, nil
+		}
+
+		allPolicies = append(allPolicies, res.Payload.Items...)
+
+		if !archerHasNextPage(res.Payload.Links) {
+			return allPolicies, nil
+		}
+
+		last := res.Payload.Items[len(res.Payload.Items)-1]
+		if last == nil {
+			return allPolicies, nil
+		}
+		opts.Marker = &last.ID
+	}
+}
@@ -91,7 +91,7 @@ func resourceSCIEndpointRBACV1Create(ctx context.Context, d *schema.ResourceData
 	}
 	res, err := client.PostRbacPolicies(opts, c.authFunc())
 	if err != nil {
-		return diag.Errorf("error creating Archer RBAC policy: %s", err)
+		return diag.Errorf("error creating Archer RBAC policy: %s", archerErrorMessage(c, err))
 	}
 	if res == nil || res.Payload == nil {
 		return diag.Errorf("error creating Archer RBAC policy: empty response")
@@ -121,11 +121,11 @@ func resourceSCIEndpointRBACV1Read(ctx context.Context, d *schema.ResourceData,
 	}
 	res, err := client.GetRbacPoliciesRbacPolicyID(opts, c.authFunc())
 	if err != nil {
-		if _, ok := err.(*rbac.GetRbacPoliciesRbacPolicyIDNotFound); ok {
+		if archerIsNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return diag.Errorf("error reading Archer RBAC policy: %s", err)
+		return diag.Errorf("error reading Archer RBAC policy: %s", archerErrorMessage(c, err))
 	}
 	if res == nil || res.Payload == nil {
 		return diag.Errorf("error reading Archer RBAC policy: empty response")
@@ -164,7 +164,7 @@ func resourceSCIEndpointRBACV1Update(ctx context.Context, d *schema.ResourceData
 	}
 	res, err := client.PutRbacPoliciesRbacPolicyID(opts, c.authFunc())
 	if err != nil {
-		return diag.Errorf("error updating Archer RBAC policy: %s", err)
+		return diag.Errorf("error updating Archer RBAC policy: %s", archerErrorMessage(c, err))
 	}
 	if res == nil || res.Payload == nil {
 		return diag.Errorf("error updating Archer RBAC policy: empty response")
@@ -190,10 +190,10 @@ func resourceSCIEndpointRBACV1Delete(ctx context.Context, d *schema.ResourceData
 	}
 	_, err = client.DeleteRbacPoliciesRbacPolicyID(opts, c.authFunc())
 	if err != nil {
-		if _, ok := err.(*rbac.DeleteRbacPoliciesRbacPolicyIDNotFound); ok {
+		if archerIsNotFound(err) {
 			return nil
 		}
-		return diag.Errorf("error deleting Archer endpoint: %s", err)
+		return diag.Errorf("error deleting Archer endpoint: %s", archerErrorMessage(c, err))
 	}
 
 	return nil
@@ -96,6 +96,15 @@ func resourceSCIEndpointServiceV1() *schema.Resource {
 				Computed: true,
 				ForceNew: true,
 			},
+			"protocol": {
+				Type: schema.TypeString,
+				ValidateFunc: validation.StringInSlice([]string{
+					models.ServiceProtocolHTTP, models.ServiceProtocolTCP,
+				}, false),
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
 			"proxy_protocol": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -176,9 +185,12 @@ func resourceSCIEndpointServiceV1Create(ctx context.Context, d *schema.ResourceD
 	if v, ok := d.GetOk("availability_zone"); ok && v != "" {
 		svc.AvailabilityZone = ptr(v.(string))
 	}
-	if v, ok := d.GetOk("svc_provider"); ok && v != "" {
+	if v, ok := d.GetOk("service_provider"); ok && v != "" {
 		svc.Provider = ptr(v.(string))
 	}
+	if v, ok := d.GetOk("protocol"); ok && v != "" {
+		svc.Protocol = ptr(v.(string))
+	}
 	if v, ok := d.GetOk("visibility"); ok && v != "" {
 		svc.Visibility = ptr(v.(string))
 	}
@@ -192,7 +204,7 @@ func resourceSCIEndpointServiceV1Create(ctx context.Context, d *schema.ResourceD
 	}
 	res, err := client.PostService(opts, c.authFunc())
 	if err != nil {
-		return diag.Errorf("error creating Archer service: %s", err)
+		return diag.Errorf("error creating Archer service: %s", archerErrorMessage(c, err))
 	}
 	if res == nil || res.Payload == nil {
 		return diag.Errorf("error creating Archer service: empty response")
@@ -227,7 +239,7 @@ func resourceSCIEndpointServiceV1Read(ctx context.Context, d *schema.ResourceDat
 	id := d.Id()
 	svc, err := archerGetService(ctx, c, id)
 	if err != nil {
-		if _, ok := err.(*service.GetServiceServiceIDNotFound); ok {
+		if archerIsNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -296,7 +308,7 @@ func resourceSCIEndpointServiceV1Update(ctx context.Context, d *schema.ResourceD
 	}
 	_, err = client.PutServiceServiceID(opts, c.authFunc())
 	if err != nil {
-		return diag.Errorf("error updating Archer service: %s", err)
+		return diag.Errorf("error updating Archer service: %s", archerErrorMessage(c, err))
 	}
 
 	// waiting for AVAILABLE status
@@ -328,10 +340,10 @@ func resourceSCIEndpointServiceV1Delete(ctx context.Context, d *schema.ResourceD
 	}
 	_, err = client.DeleteServiceServiceID(opts, c.authFunc())
 	if err != nil {
-		if _, ok := err.(*service.DeleteServiceServiceIDNotFound); ok {
+		if archerIsNotFound(err) {
 			return nil
 		}
-		return diag.Errorf("error deleting Archer service: %s", err)
+		return diag.Errorf("error deleting Archer service: %s", archerErrorMessage(c, err))
 	}
 
 	// waiting for DELETED status
@@ -360,10 +372,10 @@ func archerWaitForService(ctx context.Context, c *archer, id, target, pending st
 
 	svc, err := stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		if _, ok := err.(*service.GetServiceServiceIDNotFound); ok && target == "DELETED" {
+		if archerIsNotFound(err) && target == "DELETED" {
 			return nil, nil
 		}
-		return nil, fmt.Errorf("error waiting for %s service to become %s: %s", id, target, err)
+		return nil, fmt.Errorf("error waiting for %s service to become %s: %s", id, target, archerErrorMessage(c, err))
 	}
 
 	return svc.(*models.Service), nil
@@ -411,6 +423,7 @@ func archerSetServiceResource(d *schema.ResourceData, config *Config, svc *model
 	_ = d.Set("project_id", svc.ProjectID)
 	_ = d.Set("tags", svc.Tags)
 	_ = d.Set("service_provider", ptrValue(svc.Provider))
+	_ = d.Set("protocol", ptrValue(svc.Protocol))
 	_ = d.Set("proxy_protocol", ptrValue(svc.ProxyProtocol))
 	_ = d.Set("require_approval", ptrValue(svc.RequireApproval))
 	_ = d.Set("visibility", ptrValue(svc.Visibility))
@@ -93,6 +93,10 @@ func resourceSCIEndpointV1() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"status_reason": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"created_at": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -101,6 +105,10 @@ func resourceSCIEndpointV1() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"last_request_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -111,6 +119,8 @@ func resourceSCIEndpointV1Create(ctx context.Context, d *schema.ResourceData, me
 	if err != nil {
 		return diag.Errorf("error creating Archer client: %s", err)
 	}
+	// record the request ID of the last response, also when it failed
+	defer func() { _ = d.Set("last_request_id", c.lastRequestID()) }()
 	client := c.Endpoint
 
 	// Create the endpoint
@@ -129,7 +139,7 @@ func resourceSCIEndpointV1Create(ctx context.Context, d *schema.ResourceData, me
 	}
 	res, err := client.PostEndpoint(opts, c.authFunc())
 	if err != nil {
-		return diag.Errorf("error creating Archer endpoint: %s", err)
+		return diag.Errorf("error creating Archer endpoint: %s", archerErrorMessage(c, err))
 	}
 	if res == nil || res.Payload == nil {
 		return diag.Errorf("error creating Archer endpoint: empty response")
@@ -163,15 +173,21 @@ func resourceSCIEndpointV1Read(ctx context.Context, d *schema.ResourceData, meta
 	if err != nil {
 		return diag.Errorf("error creating Archer client: %s", err)
 	}
+	defer func() { _ = d.Set("last_request_id", c.lastRequestID()) }()
 
 	id := d.Id()
 	ept, err := archerGetEndpoint(ctx, c, id)
 	if err != nil {
-		if _, ok := err.(*endpoint.GetEndpointEndpointIDNotFound); ok {
+		if archerIsNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return diag.Errorf("error reading Archer endpoint: %s", archerErrorMessage(c, err))
+	}
+
+	switch ept.Status {
+	case models.EndpointStatusREJECTED, models.EndpointStatusFAILED:
+		log.Printf("[WARN] Archer endpoint %s is %s: %s", id, ept.Status, archerEndpointStatusReason(ept.Status))
 	}
 
 	archerSetEndpointResource(d, config, ept)
@@ -185,6 +201,7 @@ func resourceSCIEndpointV1Update(ctx context.Context, d *schema.ResourceData, me
 	if err != nil {
 		return diag.Errorf("error creating Archer client: %s", err)
 	}
+	defer func() { _ = d.Set("last_request_id", c.lastRequestID()) }()
 	client := c.Endpoint
 
 	id := d.Id()
@@ -207,7 +224,7 @@ func resourceSCIEndpointV1Update(ctx context.Context, d *schema.ResourceData, me
 	}
 	res, err := client.PutEndpointEndpointID(opts, c.authFunc())
 	if err != nil {
-		return diag.Errorf("error updating Archer endpoint: %s", err)
+		return diag.Errorf("error updating Archer endpoint: %s", archerErrorMessage(c, err))
 	}
 	if res == nil || res.Payload == nil {
 		return diag.Errorf("error updating Archer endpoint: empty response")
@@ -224,6 +241,7 @@ func resourceSCIEndpointV1Delete(ctx context.Context, d *schema.ResourceData, me
 	if err != nil {
 		return diag.Errorf("error creating Archer client: %s", err)
 	}
+	defer func() { _ = d.Set("last_request_id", c.lastRequestID()) }()
 	client := c.Endpoint
 
 	id := d.Id()
@@ -233,10 +251,10 @@ func resourceSCIEndpointV1Delete(ctx context.Context, d *schema.ResourceData, me
 	}
 	_, err = client.DeleteEndpointEndpointID(opts, c.authFunc())
 	if err != nil {
-		if _, ok := err.(*endpoint.DeleteEndpointEndpointIDNotFound); ok {
+		if archerIsNotFound(err) {
 			return nil
 		}
-		return diag.Errorf("error deleting Archer endpoint: %s", err)
+		return diag.Errorf("error deleting Archer endpoint: %s", archerErrorMessage(c, err))
 	}
 
 	// waiting for DELETED status
@@ -265,10 +283,10 @@ func archerWaitForEndpoint(ctx context.Context, c *archer, id string, target []s
 
 	ept, err := stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		if _, ok := err.(*endpoint.GetEndpointEndpointIDNotFound); ok && target[0] == "DELETED" {
+		if archerIsNotFound(err) && target[0] == "DELETED" {
 			return nil, nil
 		}
-		return nil, fmt.Errorf("error waiting for %s endpoint to become %s: %s", id, target, err)
+		return nil, fmt.Errorf("error waiting for %s endpoint to become %s: %s", id, target, archerErrorMessage(c, err))
 	}
 
 	return ept.(*models.Endpoint), nil
@@ -312,12 +330,37 @@ func archerSetEndpointResource(d *schema.ResourceData, config *Config, ept *mode
 
 	// computed
 	_ = d.Set("status", ept.Status)
+	_ = d.Set("status_reason", archerEndpointStatusReason(ept.Status))
 	_ = d.Set("created_at", ept.CreatedAt.String())
 	_ = d.Set("updated_at", ept.UpdatedAt.String())
 
 	_ = d.Set("region", GetRegion(d, config))
 }
 
+// archerEndpointStatusReason describes the endpoint status. The Archer API
+// does not return a reason, so the descriptions follow the API spec.
+func archerEndpointStatusReason(status models.EndpointStatus) string {
+	switch status {
+	case models.EndpointStatusAVAILABLE:
+		return "endpoint is available for consumption"
+	case models.EndpointStatusPENDINGAPPROVAL:
+		return "endpoint is waiting for approval by the service owner"
+	case models.EndpointStatusPENDINGCREATE:
+		return "endpoint is being set up"
+	case models.EndpointStatusPENDINGUPDATE:
+		return "endpoint is being updated"
+	case models.EndpointStatusPENDINGREJECTED:
+		return "endpoint is being rejected by the service owner"
+	case models.EndpointStatusPENDINGDELETE:
+		return "endpoint is being deleted"
+	case models.EndpointStatusREJECTED:
+		return "endpoint was rejected by the service owner"
+	case models.EndpointStatusFAILED:
+		return "endpoint setup failed"
+	}
+	return ""
+}
+
 func expandEndpointTarget(target models.EndpointTarget) []map[string]string {
 	return []map[string]string{
 		{
@@ -37,6 +37,11 @@ func resourceSCIGSLBDomainV1() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			"force_delete": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"aliases": {
 				Type:     schema.TypeList,
 				Elem:     &schema.Schema{Type: schema.TypeString},
@@ -283,6 +288,15 @@ func resourceSCIGSLBDomainV1Delete(ctx context.Context, d *schema.ResourceData,
 	client := c.Domains
 
 	id := d.Id()
+	timeout := d.Timeout(schema.TimeoutDelete)
+
+	if d.Get("force_delete").(bool) {
+		err = andromedaDetachDomainPools(ctx, client, id, timeout)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	opts := &domains.DeleteDomainsDomainIDParams{
 		DomainID: strfmt.UUID(id),
 		Context:  ctx,
@@ -296,7 +310,6 @@ func resourceSCIGSLBDomainV1Delete(ctx context.Context, d *schema.ResourceData,
 	}
 
 	// waiting for DELETED status
-	timeout := d.Timeout(schema.TimeoutDelete)
 	target := "DELETED"
 	pending := models.DomainProvisioningStatusPENDINGDELETE
 	_, err = andromedaWaitForDomain(ctx, client, id, target, pending, timeout)
@@ -307,6 +320,45 @@ func resourceSCIGSLBDomainV1Delete(ctx context.Context, d *schema.ResourceData,
 	return nil
 }
 
+// andromedaDetachDomainPools removes all the pools from the domain, so that it
+// can be deleted. The pools themselves are kept.
+func andromedaDetachDomainPools(ctx context.Context, client domains.ClientService, id string, timeout time.Duration) error {
+	domain, err := andromedaGetDomain(ctx, client, id)
+	if err != nil {
+		if _, ok := err.(*domains.GetDomainsDomainIDNotFound); ok {
+			return nil
+		}
+		return fmt.Errorf("error reading Andromeda domain: %s", err)
+	}
+	if len(domain.Pools) == 0 {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Detaching pools %v from Andromeda domain %s before deleting it", domain.Pools, id)
+
+	opts := &domains.PutDomainsDomainIDParams{
+		Domain: domains.PutDomainsDomainIDBody{
+			Domain: &models.Domain{
+				Fqdn:     domain.Fqdn,
+				Provider: domain.Provider,
+				Pools:    []strfmt.UUID{},
+			},
+		},
+		DomainID: strfmt.UUID(id),
+		Context:  ctx,
+	}
+	_, err = client.PutDomainsDomainID(opts)
+	if err != nil {
+		return fmt.Errorf("error detaching pools from Andromeda domain: %s", err)
+	}
+
+	target := models.DomainProvisioningStatusACTIVE
+	pending := models.DomainProvisioningStatusPENDINGUPDATE
+	_, err = andromedaWaitForDomain(ctx, client, id, target, pending, timeout)
+
+	return err
+}
+
 func andromedaWaitForDomain(ctx context.Context, client domains.ClientService, id, target, pending string, timeout time.Duration) (*models.Domain, error) {
 	log.Printf("[DEBUG] Waiting for %s domain to become %s.", id, target)
 
@@ -46,7 +46,26 @@ func resourceSCIGSLBGeoMapV1() *schema.Resource {
 						},
 					},
 				},
-				Optional: true,
+				Optional:      true,
+				ConflictsWith: []string{"assignment"},
+			},
+			"assignment": {
+				Type: schema.TypeSet,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"country_codes": {
+							Type:     schema.TypeSet,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Required: true,
+						},
+						"datacenter": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+				Optional:      true,
+				ConflictsWith: []string{"assignments"},
 			},
 			"default_datacenter": {
 				Type:     schema.TypeString,
@@ -122,6 +141,9 @@ func resourceSCIGSLBGeoMapV1Create(ctx context.Context, d *schema.ResourceData,
 	if v, ok := d.GetOk("assignments"); ok {
 		geomap.Assignments = andromedaExpandGeoMapAssignments(v.([]any))
 	}
+	if v, ok := d.GetOk("assignment"); ok {
+		geomap.Assignments = andromedaExpandGeoMapCountryAssignments(v.(*schema.Set).List())
+	}
 
 	opts := &geomaps.PostGeomapsParams{
 		Geomap: geomaps.PostGeomapsBody{
@@ -207,9 +229,13 @@ func resourceSCIGSLBGeoMapV1Update(ctx context.Context, d *schema.ResourceData,
 		v := d.Get("scope").(string)
 		geomap.Scope = &v
 	}
-	if d.HasChange("assignments") {
-		v := d.Get("assignments").([]any)
-		geomap.Assignments = andromedaExpandGeoMapAssignments(v)
+	if d.HasChanges("assignments", "assignment") {
+		if v, ok := d.GetOk("assignment"); ok {
+			geomap.Assignments = andromedaExpandGeoMapCountryAssignments(v.(*schema.Set).List())
+		} else {
+			v := d.Get("assignments").([]any)
+			geomap.Assignments = andromedaExpandGeoMapAssignments(v)
+		}
 	}
 
 	opts := &geomaps.PutGeomapsGeomapIDParams{
@@ -327,7 +353,11 @@ func andromedaSetGeoMapResource(d *schema.ResourceData, config *Config, geomap *
 	_ = d.Set("project_id", ptrValue(geomap.ProjectID))
 	_ = d.Set("service_provider", geomap.Provider)
 	_ = d.Set("scope", ptrValue(geomap.Scope))
-	_ = d.Set("assignments", andromedaFlattenGeoMapAssignments(geomap.Assignments))
+	if _, ok := d.GetOk("assignment"); ok {
+		_ = d.Set("assignment", andromedaFlattenGeoMapCountryAssignments(geomap.Assignments))
+	} else {
+		_ = d.Set("assignments", andromedaFlattenGeoMapAssignments(geomap.Assignments))
+	}
 
 	// computed
 	_ = d.Set("provisioning_status", geomap.ProvisioningStatus)
@@ -361,3 +391,39 @@ func andromedaExpandGeoMapAssignments(v []any) []*models.GeomapAssignmentsItems0
 	}
 	return res
 }
+
+// andromedaFlattenGeoMapCountryAssignments groups the assignments by their
+// datacenter, so that they can be represented as "assignment" blocks.
+func andromedaFlattenGeoMapCountryAssignments(assignments []*models.GeomapAssignmentsItems0) []map[string]any {
+	var res []map[string]any
+	idx := make(map[string]int)
+	for _, assignment := range assignments {
+		datacenter := assignment.Datacenter.String()
+		i, ok := idx[datacenter]
+		if !ok {
+			i = len(res)
+			idx[datacenter] = i
+			res = append(res, map[string]any{
+				"datacenter":    datacenter,
+				"country_codes": []string{},
+			})
+		}
+		res[i]["country_codes"] = append(res[i]["country_codes"].([]string), assignment.Country)
+	}
+	return res
+}
+
+func andromedaExpandGeoMapCountryAssignments(v []any) []*models.GeomapAssignmentsItems0 {
+	var res []*models.GeomapAssignmentsItems0
+	for _, v := range v {
+		v := v.(map[string]any)
+		datacenter := v["datacenter"].(string)
+		for _, country := range v["country_codes"].(*schema.Set).List() {
+			res = append(res, &models.GeomapAssignmentsItems0{
+				Country:    country.(string),
+				Datacenter: strfmt.UUID(datacenter),
+			})
+		}
+	}
+	return res
+}
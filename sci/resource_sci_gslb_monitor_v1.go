@@ -25,6 +25,8 @@ func resourceSCIGSLBMonitorV1() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: andromedaValidateMonitorHTTPOptions,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -388,3 +390,23 @@ func andromedaSetMonitorResource(d *schema.ResourceData, config *Config, monitor
 
 	_ = d.Set("region", GetRegion(d, config))
 }
+
+// andromedaValidateMonitorHTTPOptions rejects the HTTP specific options for
+// monitors, which don't use HTTP.
+func andromedaValidateMonitorHTTPOptions(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	// the type may be unknown during the plan
+	if !d.NewValueKnown("type") {
+		return nil
+	}
+
+	switch d.Get("type").(string) {
+	case "HTTP", "HTTPS":
+		return nil
+	}
+
+	if v, ok := d.GetOk("domain_name"); ok && v.(string) != "" {
+		return fmt.Errorf("domain_name can only be used with the HTTP and HTTPS monitor types")
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package sci
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"strconv"
@@ -9,6 +10,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/sapcc/kubernikus/pkg/api/client/operations"
@@ -17,7 +19,14 @@ import (
 
 func resourceSCIKubernetesV1() *schema.Resource {
 	return &schema.Resource{
-		SchemaVersion: 1,
+		SchemaVersion: 2,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceSCIKubernetesV1V1().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceSCIKubernetesV1StateUpgradeV1,
+				Version: 1,
+			},
+		},
 
 		ReadContext:   resourceSCIKubernetesV1Read,
 		UpdateContext: resourceSCIKubernetesV1Update,
@@ -27,6 +36,17 @@ func resourceSCIKubernetesV1() *schema.Resource {
 			StateContext: resourceSCIKubernetesV1Import,
 		},
 
+		CustomizeDiff: customdiff.All(
+			kubernikusValidateNodePoolNamesV1,
+			kubernikusValidateCIDRChangeV1,
+			customdiff.IfValueChange("version",
+				func(ctx context.Context, old, new, meta any) bool {
+					return old.(string) != "" && old.(string) != new.(string)
+				},
+				kubernikusPlanUpgradeNodePoolsV1,
+			),
+		),
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
 			Update: schema.DefaultTimeout(30 * time.Minute),
@@ -105,6 +125,12 @@ func resourceSCIKubernetesV1() *schema.Resource {
 				ValidateFunc: validation.IsCIDRNetwork(8, 24),
 			},
 
+			"allow_cidr_change": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"dns_address": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -114,15 +140,17 @@ func resourceSCIKubernetesV1() *schema.Resource {
 			},
 
 			"dns_domain": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Computed:     true,
+				ValidateFunc: kubernikusValidateDNSDomain,
 			},
 
 			"ssh_public_key": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: kubernikusValidateSSHPublicKey,
 			},
 
 			"no_cloud": {
@@ -187,6 +215,18 @@ func resourceSCIKubernetesV1() *schema.Resource {
 				ValidateFunc: validateKubernetesVersion,
 			},
 
+			"default_node_labels": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"default_node_taints": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
 			"node_pools": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -259,6 +299,17 @@ func resourceSCIKubernetesV1() *schema.Resource {
 				},
 			},
 
+			"fast_node_pool_delete": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"node_pools_name_prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
 			"openstack": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -314,6 +365,36 @@ func resourceSCIKubernetesV1() *schema.Resource {
 				Computed: true,
 			},
 
+			"apiserver_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"ready": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"oidc_configured": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"audit_configured": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"dex_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"backup_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
 			"apiserver_url": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -329,37 +410,16 @@ func resourceSCIKubernetesV1() *schema.Resource {
 				Computed:  true,
 				Sensitive: true,
 				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"host": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						"username": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						"client_certificate": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						"client_key": {
-							Type:      schema.TypeString,
-							Computed:  true,
-							Sensitive: true,
-						},
-						"cluster_ca_certificate": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						"not_before": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						"not_after": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-					},
+					Schema: kubernikusKubeConfigSchemaV1(),
+				},
+			},
+
+			"kube_config_contexts": {
+				Type:      schema.TypeList,
+				Computed:  true,
+				Sensitive: true,
+				Elem: &schema.Resource{
+					Schema: kubernikusKubeConfigContextSchemaV1(),
 				},
 			},
 
@@ -368,11 +428,33 @@ func resourceSCIKubernetesV1() *schema.Resource {
 				Computed:  true,
 				Sensitive: true,
 			},
+
+			"last_request_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"upgrade_node_pools": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"spec_json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"store_kube_config": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
 		},
 	}
 }
 
-func resourceSCIKubernetesV1Create(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+func resourceSCIKubernetesV1Create(ctx context.Context, d *schema.ResourceData, meta any) (diags diag.Diagnostics) {
 	config := meta.(*Config)
 	log.Printf("[KUBERNETES] Creating Kubernikus Kluster in project %s", config.TenantID)
 
@@ -380,6 +462,16 @@ func resourceSCIKubernetesV1Create(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		return diag.Errorf("Error creating Kubernikus client: %s", err)
 	}
+	// the final Read records the request ID, when the create succeeds
+	defer func() {
+		if diags.HasError() {
+			_ = d.Set("last_request_id", klient.lastRequestID())
+		}
+	}()
+
+	mutexKey := kubernikusMutexKeyV1(GetRegion(d, config), d.Get("name").(string))
+	config.MutexKV.Lock(mutexKey)
+	defer config.MutexKV.Unlock(mutexKey)
 
 	cluster := &models.Kluster{
 		Spec: models.KlusterSpec{
@@ -424,7 +516,8 @@ func resourceSCIKubernetesV1Create(ctx context.Context, d *schema.ResourceData,
 		}
 		cluster.Spec.Version = v
 	}
-	cluster.Spec.NodePools, err = kubernikusExpandNodePoolsV1(d.Get("node_pools"))
+	defaults := kubernikusExpandNodePoolDefaultsV1(d.Get("default_node_labels"), d.Get("default_node_taints"))
+	cluster.Spec.NodePools, err = kubernikusExpandNodePoolsV1(d.Get("node_pools"), defaults)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -434,7 +527,7 @@ func resourceSCIKubernetesV1Create(ctx context.Context, d *schema.ResourceData,
 
 	_, err = klient.CreateCluster(operations.NewCreateClusterParams().WithBody(cluster), klient.authFunc())
 	if err != nil {
-		return diag.FromErr(kubernikusHandleErrorV1("Error creating cluster", err))
+		return diag.FromErr(kubernikusHandleErrorV1(klient, "Error creating cluster", err))
 	}
 
 	d.SetId(cluster.Name)
@@ -449,7 +542,14 @@ func resourceSCIKubernetesV1Create(ctx context.Context, d *schema.ResourceData,
 	}
 	err = kubernikusWaitForClusterV1(ctx, klient, cluster.Name, target, pending, timeout)
 	if err != nil {
-		return diag.FromErr(kubernikusHandleErrorV1("Error waiting for running cluster state", err))
+		return diag.FromErr(kubernikusHandleErrorV1(klient, "Error waiting for running cluster state", err))
+	}
+
+	if d.Get("dashboard").(bool) {
+		err = kubernikusWaitForDashboardV1(ctx, klient, cluster.Name, timeout)
+		if err != nil {
+			return diag.FromErr(kubernikusHandleErrorV1(klient, "Error waiting for the cluster dashboard", err))
+		}
 	}
 
 	return resourceSCIKubernetesV1Read(ctx, d, meta)
@@ -463,19 +563,20 @@ func resourceSCIKubernetesV1Read(ctx context.Context, d *schema.ResourceData, me
 	if err != nil {
 		return diag.Errorf("Error creating Kubernikus client: %s", err)
 	}
+	defer func() { _ = d.Set("last_request_id", klient.lastRequestID()) }()
 
-	result, err := klient.ShowCluster(operations.NewShowClusterParams().WithName(d.Id()), klient.authFunc())
+	result, err := klient.ShowCluster(operations.NewShowClusterParams().WithContext(ctx).WithName(d.Id()), klient.authFunc())
 	if err != nil {
+		if kubernikusIsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+
 		switch res := err.(type) {
 		case *operations.ShowClusterDefault:
-			if res.Payload.Message == "Not found" {
-				d.SetId("")
-				return nil
-			}
-
-			return diag.Errorf("Error reading Kubernikus cluster: %s", res.Payload.Message)
+			return diag.Errorf("Error reading Kubernikus cluster: %s", withRequestID(res.Payload.Message, klient.lastRequestID()))
 		case error:
-			return diag.Errorf("Error reading Kubernikus cluster: %s", err)
+			return diag.Errorf("Error reading Kubernikus cluster: %s", withRequestID(err.Error(), klient.lastRequestID()))
 		}
 		return diag.FromErr(err)
 	}
@@ -497,28 +598,53 @@ func resourceSCIKubernetesV1Read(ctx context.Context, d *schema.ResourceData, me
 	_ = d.Set("service_cidr", result.Payload.Spec.ServiceCIDR)
 	_ = d.Set("version", result.Payload.Spec.Version)
 	_ = d.Set("phase", result.Payload.Status.Phase)
-	_ = d.Set("wormhole", result.Payload.Status.Wormhole)
+	_ = d.Set("wormhole", kubernikusNormalizeURL(result.Payload.Status.Wormhole))
+	_ = d.Set("apiserver_version", result.Payload.Status.ApiserverVersion)
+	_ = d.Set("ready", kubernikusKlusterV1Phase(result.Payload) == string(models.KlusterPhaseRunning))
+	_ = d.Set("oidc_configured", kubernikusFlattenOIDCV1(result.Payload.Spec.Oidc) != nil)
+	_ = d.Set("audit_configured", ptrValue(result.Payload.Spec.Audit) != "")
+	_ = d.Set("dex_enabled", ptrValue(result.Payload.Spec.Dex))
+	_ = d.Set("backup_enabled", sliceContains([]string{models.KlusterSpecBackupOn, models.KlusterSpecBackupExternalAWS}, result.Payload.Spec.Backup))
 	_ = d.Set("apiserver_url", result.Payload.Status.Apiserver)
 	_ = d.Set("dashboard_url", result.Payload.Status.Dashboard)
 	_ = d.Set("openstack", kubernikusFlattenOpenstackSpecV1(&result.Payload.Spec.Openstack))
-	_ = d.Set("node_pools", kubernikusFlattenNodePoolsV1(result.Payload.Spec.NodePools))
+	managed, _ := kubernikusFilterNodePoolsV1(result.Payload.Spec.NodePools, d.Get("node_pools_name_prefix").(string))
+	nodePools := kubernikusFlattenNodePoolsV1(managed)
+	defaults := kubernikusExpandNodePoolDefaultsV1(d.Get("default_node_labels"), d.Get("default_node_taints"))
+	kubernikusStripNodePoolDefaultsV1(nodePools, d.Get("node_pools"), defaults)
+	_ = d.Set("node_pools", nodePools)
 
 	_ = d.Set("region", GetRegion(d, config))
 
+	spec, err := json.MarshalIndent(result.Payload.Spec, "", "  ")
+	if err != nil {
+		return diag.Errorf("Error marshaling Kubernikus cluster spec: %s", err)
+	}
+	_ = d.Set("spec_json", string(spec))
+
+	if !d.Get("store_kube_config").(bool) {
+		// don't keep the credentials in the state
+		_ = d.Set("kube_config", nil)
+		_ = d.Set("kube_config_contexts", nil)
+		_ = d.Set("kube_config_raw", "")
+		return nil
+	}
+
 	// if cluster is in pending state, than there are no credentials yet
 	if result.Payload.Status.Phase != models.KlusterPhasePending {
-		kubeConfigRaw, kubeConfig, err := getCredentials(klient, d.Id(), d.Get("kube_config_raw").(string))
+		kubeConfigRaw, contexts, err := getCredentials(klient, d.Id(), d.Get("kube_config_raw").(string))
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		_ = d.Set("kube_config", kubeConfig)
+		_ = d.Set("kube_config", kubernikusFlattenKubeConfigV1(contexts))
+		_ = d.Set("kube_config_contexts", contexts)
 		_ = d.Set("kube_config_raw", kubeConfigRaw)
 	}
 
 	return nil
 }
 
-func resourceSCIKubernetesV1Update(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+func resourceSCIKubernetesV1Update(ctx context.Context, d *schema.ResourceData, meta any) (diags diag.Diagnostics) {
 	config := meta.(*Config)
 	log.Printf("[KUBERNETES] Updating Kubernikus Kluster in project %s", config.TenantID)
 
@@ -526,8 +652,19 @@ func resourceSCIKubernetesV1Update(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		return diag.Errorf("Error creating Kubernikus client: %s", err)
 	}
+	// the final Read records the request ID, when the update succeeds
+	defer func() {
+		if diags.HasError() {
+			_ = d.Set("last_request_id", klient.lastRequestID())
+		}
+	}()
 
-	timeout := d.Timeout(schema.TimeoutUpdate)
+	mutexKey := kubernikusMutexKeyV1(GetRegion(d, config), d.Id())
+	config.MutexKV.Lock(mutexKey)
+	defer config.MutexKV.Unlock(mutexKey)
+
+	// all update stages share the update timeout
+	deadline := time.Now().Add(d.Timeout(schema.TimeoutUpdate))
 	cluster := &models.Kluster{
 		Spec: models.KlusterSpec{
 			NodePools: []models.NodePool{},
@@ -578,7 +715,21 @@ func resourceSCIKubernetesV1Update(ctx context.Context, d *schema.ResourceData,
 		cluster.Spec.Openstack.SecurityGroupName = v.(string)
 	}
 
+	oldLabels, newLabels := d.GetChange("default_node_labels")
+	oldTaints, newTaints := d.GetChange("default_node_taints")
 	o, n := d.GetChange("node_pools")
+	if d.HasChange("version") {
+		// store the same list, which was shown in the plan
+		_ = d.Set("upgrade_node_pools", kubernikusUpgradeNodePoolsV1(o.([]any), n.([]any)))
+	}
+	oldNodePools, err := kubernikusExpandNodePoolsV1(o, kubernikusExpandNodePoolDefaultsV1(oldLabels, oldTaints))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	newNodePools, err := kubernikusExpandNodePoolsV1(n, kubernikusExpandNodePoolDefaultsV1(newLabels, newTaints))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	// wait for the cluster to be upgraded, when new API version was specified
 	target := string(models.KlusterPhaseRunning)
@@ -588,9 +739,36 @@ func resourceSCIKubernetesV1Update(ctx context.Context, d *schema.ResourceData,
 		string(models.KlusterPhaseUpgrading),
 		string(models.KlusterPhaseTerminating),
 	}
-	err = kubernikusUpdateNodePoolsV1(ctx, klient, cluster, o, n, target, pending, timeout)
+
+	// keep the node pools, which are managed outside of this resource
+	var unmanaged []models.NodePool
+	prefix := d.Get("node_pools_name_prefix").(string)
+	if prefix != "" {
+		result, err := klient.ShowCluster(operations.NewShowClusterParams().WithContext(ctx).WithName(d.Id()), klient.authFunc())
+		if err != nil {
+			return diag.FromErr(kubernikusHandleErrorV1(klient, "Error reading Kubernikus cluster", err))
+		}
+		_, unmanaged = kubernikusFilterNodePoolsV1(result.Payload.Spec.NodePools, prefix)
+	}
+
+	var changed []string
+	for _, key := range kubernikusSpecFieldsV1 {
+		if d.HasChange(key) {
+			changed = append(changed, key)
+		}
+	}
+
+	fastDelete := d.Get("fast_node_pool_delete").(bool)
+	err = kubernikusUpdateNodePoolsV1(ctx, klient, cluster, changed, prefix, oldNodePools, newNodePools, unmanaged, fastDelete, target, pending, deadline)
 	if err != nil {
-		return diag.FromErr(kubernikusHandleErrorV1("Error waiting for cluster to be updated", err))
+		return diag.Errorf("Error waiting for cluster to be updated: %s", err)
+	}
+
+	if d.HasChange("dashboard") && d.Get("dashboard").(bool) {
+		err = kubernikusWaitForDashboardV1(ctx, klient, cluster.Name, time.Until(deadline))
+		if err != nil {
+			return diag.FromErr(kubernikusHandleErrorV1(klient, "Error waiting for the cluster dashboard", err))
+		}
 	}
 
 	return resourceSCIKubernetesV1Read(ctx, d, meta)
@@ -604,12 +782,17 @@ func resourceSCIKubernetesV1Delete(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		return diag.Errorf("Error creating Kubernikus client: %s", err)
 	}
+	defer func() { _ = d.Set("last_request_id", klient.lastRequestID()) }()
+
+	mutexKey := kubernikusMutexKeyV1(GetRegion(d, config), d.Id())
+	config.MutexKV.Lock(mutexKey)
+	defer config.MutexKV.Unlock(mutexKey)
 
 	timeout := d.Timeout(schema.TimeoutDelete)
 
 	_, err = klient.TerminateCluster(operations.NewTerminateClusterParams().WithName(d.Id()), klient.authFunc())
 	if err != nil {
-		return diag.FromErr(kubernikusHandleErrorV1("Error deleting cluster", err))
+		return diag.FromErr(kubernikusHandleErrorV1(klient, "Error deleting cluster", err))
 	}
 
 	target := "Terminated"
@@ -622,7 +805,7 @@ func resourceSCIKubernetesV1Delete(ctx context.Context, d *schema.ResourceData,
 	}
 	err = kubernikusWaitForClusterV1(ctx, klient, d.Id(), target, pending, timeout)
 	if err != nil {
-		return diag.FromErr(kubernikusHandleErrorV1("Error waiting for cluster to be deleted", err))
+		return diag.FromErr(kubernikusHandleErrorV1(klient, "Error waiting for cluster to be deleted", err))
 	}
 
 	return nil
@@ -651,3 +834,81 @@ func resourceSCIKubernetesV1Import(ctx context.Context, d *schema.ResourceData,
 
 	return []*schema.ResourceData{d}, nil
 }
+
+func kubernikusKubeConfigSchemaV1() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"host": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"username": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"client_certificate": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"client_key": {
+			Type:      schema.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+		"cluster_ca_certificate": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"not_before": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"not_after": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"token": {
+			Type:      schema.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+		"exec": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"api_version": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"command": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"args": {
+						Type:     schema.TypeList,
+						Computed: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+					"env": {
+						Type:     schema.TypeMap,
+						Computed: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+		},
+	}
+}
+
+func kubernikusKubeConfigContextSchemaV1() map[string]*schema.Schema {
+	s := kubernikusKubeConfigSchemaV1()
+	s["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	s["current"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Computed: true,
+	}
+	return s
+}
@@ -0,0 +1,417 @@
+// This augments the following upstream resource to manage the SAP Cloud Infrastructure specific external_port_id
+// https://github.com/terraform-provider-openstack/terraform-provider-openstack/blob/74d82f6ce503df74a5e63ac2491e837dc296a82b/openstack/resource_openstack_networking_router_v2.go
+
+package sci
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/attributestags"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/layer3/routers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ccRouterCreateOpts overrides the upstream external_gateway_info with the
+// SAP Cloud Infrastructure specific GatewayInfo.
+type ccRouterCreateOpts struct {
+	routers.CreateOpts
+	CCGatewayInfo *GatewayInfo
+}
+
+func (opts ccRouterCreateOpts) ToRouterCreateMap() (map[string]any, error) {
+	b, err := opts.CreateOpts.ToRouterCreateMap()
+	if err != nil {
+		return nil, err
+	}
+
+	return networkingRouterV2SetGatewayInfo(b, opts.CCGatewayInfo)
+}
+
+// ccRouterUpdateOpts overrides the upstream external_gateway_info with the
+// SAP Cloud Infrastructure specific GatewayInfo.
+type ccRouterUpdateOpts struct {
+	routers.UpdateOpts
+	CCGatewayInfo *GatewayInfo
+}
+
+func (opts ccRouterUpdateOpts) ToRouterUpdateMap() (map[string]any, error) {
+	b, err := opts.UpdateOpts.ToRouterUpdateMap()
+	if err != nil {
+		return nil, err
+	}
+
+	return networkingRouterV2SetGatewayInfo(b, opts.CCGatewayInfo)
+}
+
+func networkingRouterV2SetGatewayInfo(b map[string]any, gatewayInfo *GatewayInfo) (map[string]any, error) {
+	if gatewayInfo == nil {
+		return b, nil
+	}
+
+	gw, err := gophercloud.BuildRequestBody(gatewayInfo, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if r, ok := b["router"].(map[string]any); ok {
+		r["external_gateway_info"] = gw
+	}
+
+	return b, nil
+}
+
+func resourceSCINetworkingRouterV2() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSCINetworkingRouterV2Create,
+		ReadContext:   resourceSCINetworkingRouterV2Read,
+		UpdateContext: resourceSCINetworkingRouterV2Update,
+		DeleteContext: resourceSCINetworkingRouterV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"admin_state_up": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"distributed": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"external_network_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"external_port_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				RequiredWith: []string{"external_network_id"},
+			},
+			"enable_snat": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"qos_policy_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				RequiredWith: []string{"external_network_id"},
+			},
+			"external_fixed_ip": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"subnet_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"ip_address": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"availability_zone_hints": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"all_tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceSCINetworkingRouterV2Create(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	config := meta.(*Config)
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
+	}
+
+	createOpts := ccRouterCreateOpts{
+		CreateOpts: routers.CreateOpts{
+			Name:                  d.Get("name").(string),
+			Description:           d.Get("description").(string),
+			TenantID:              d.Get("tenant_id").(string),
+			AvailabilityZoneHints: expandToStringSlice(d.Get("availability_zone_hints").([]any)),
+		},
+		CCGatewayInfo: expandNetworkingRouterV2GatewayInfo(d),
+	}
+
+	if v, ok := getOkExists(d, "admin_state_up"); ok {
+		asu := v.(bool)
+		createOpts.AdminStateUp = &asu
+	}
+
+	if v, ok := getOkExists(d, "distributed"); ok {
+		dist := v.(bool)
+		createOpts.Distributed = &dist
+	}
+
+	log.Printf("[DEBUG] Create Router: %#v", createOpts)
+
+	router, err := routers.Create(ctx, networkingClient, createOpts).Extract()
+	if err != nil {
+		return diag.Errorf("Error creating Router: %s", err)
+	}
+
+	log.Printf("[DEBUG] Router created: %#v", router)
+
+	d.SetId(router.ID)
+
+	stateConf := &retry.StateChangeConf{
+		Pending:    []string{"BUILD", "PENDING_CREATE", "PENDING_UPDATE"},
+		Target:     []string{"ACTIVE"},
+		Refresh:    networkingRouterV2StateRefreshFunc(ctx, networkingClient, router.ID),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("Error waiting for Router %s to become ACTIVE: %s", router.ID, err)
+	}
+
+	tags := expandObjectTags(d)
+	if len(tags) > 0 {
+		tagOpts := attributestags.ReplaceAllOpts{Tags: tags}
+		tags, err := attributestags.ReplaceAll(ctx, networkingClient, "routers", router.ID, tagOpts).Extract()
+		if err != nil {
+			return diag.Errorf("Error setting tags on Router %s: %s", router.ID, err)
+		}
+		log.Printf("[DEBUG] Set tags %s on Router %s", tags, router.ID)
+	}
+
+	return resourceSCINetworkingRouterV2Read(ctx, d, meta)
+}
+
+func resourceSCINetworkingRouterV2Read(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	config := meta.(*Config)
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
+	}
+
+	router, err := networkingRouterV2Get(ctx, networkingClient, d.Id())
+	if err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "router"))
+	}
+
+	log.Printf("[DEBUG] Read Router %s: %#v", d.Id(), router)
+
+	_ = d.Set("name", router.Name)
+	_ = d.Set("description", router.Description)
+	_ = d.Set("admin_state_up", router.AdminStateUp)
+	_ = d.Set("distributed", router.Distributed)
+	_ = d.Set("tenant_id", router.TenantID)
+	_ = d.Set("external_network_id", router.CCGatewayInfo.NetworkID)
+	_ = d.Set("external_port_id", router.CCGatewayInfo.ExternalPortID)
+	_ = d.Set("enable_snat", router.CCGatewayInfo.EnableSNAT)
+	_ = d.Set("qos_policy_id", router.CCGatewayInfo.QoSPolicyID)
+	_ = d.Set("all_tags", router.Tags)
+	_ = d.Set("region", GetRegion(d, config))
+
+	if err := d.Set("availability_zone_hints", router.AvailabilityZoneHints); err != nil {
+		log.Printf("[DEBUG] Unable to set availability_zone_hints: %s", err)
+	}
+
+	if err = d.Set("external_fixed_ip", flattenNetworkingRouterV2ExternalFixedIPs(router.CCGatewayInfo.ExternalFixedIPs)); err != nil {
+		log.Printf("[DEBUG] Unable to set external_fixed_ip: %s", err)
+	}
+
+	return nil
+}
+
+func resourceSCINetworkingRouterV2Update(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	config := meta.(*Config)
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
+	}
+
+	var hasChange bool
+	var updateOpts ccRouterUpdateOpts
+
+	if d.HasChange("name") {
+		hasChange = true
+		updateOpts.Name = d.Get("name").(string)
+	}
+
+	if d.HasChange("description") {
+		hasChange = true
+		description := d.Get("description").(string)
+		updateOpts.Description = &description
+	}
+
+	if d.HasChange("admin_state_up") {
+		hasChange = true
+		asu := d.Get("admin_state_up").(bool)
+		updateOpts.AdminStateUp = &asu
+	}
+
+	if d.HasChanges("external_network_id", "external_port_id", "enable_snat", "qos_policy_id", "external_fixed_ip") {
+		hasChange = true
+		updateOpts.CCGatewayInfo = expandNetworkingRouterV2GatewayInfo(d)
+		if updateOpts.CCGatewayInfo == nil {
+			// an empty gateway info removes the external gateway
+			updateOpts.CCGatewayInfo = &GatewayInfo{}
+		}
+	}
+
+	if hasChange {
+		log.Printf("[DEBUG] Updating Router %s with options: %#v", d.Id(), updateOpts)
+
+		_, err = routers.Update(ctx, networkingClient, d.Id(), updateOpts).Extract()
+		if err != nil {
+			return diag.Errorf("Error updating Router %s: %s", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("tags") {
+		tags := expandObjectTags(d)
+		tagOpts := attributestags.ReplaceAllOpts{Tags: tags}
+		tags, err := attributestags.ReplaceAll(ctx, networkingClient, "routers", d.Id(), tagOpts).Extract()
+		if err != nil {
+			return diag.Errorf("Error setting tags on Router %s: %s", d.Id(), err)
+		}
+		log.Printf("[DEBUG] Set tags %s on Router %s", tags, d.Id())
+	}
+
+	return resourceSCINetworkingRouterV2Read(ctx, d, meta)
+}
+
+func resourceSCINetworkingRouterV2Delete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	config := meta.(*Config)
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
+	}
+
+	if err := routers.Delete(ctx, networkingClient, d.Id()).ExtractErr(); err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error deleting Router"))
+	}
+
+	stateConf := &retry.StateChangeConf{
+		Pending:    []string{"ACTIVE"},
+		Target:     []string{"DELETED"},
+		Refresh:    networkingRouterV2StateRefreshFunc(ctx, networkingClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("Error waiting for Router %s to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func networkingRouterV2Get(ctx context.Context, client *gophercloud.ServiceClient, id string) (*ccRouter, error) {
+	var router ccRouter
+	err := routers.Get(ctx, client, id).ExtractIntoStructPtr(&router, "router")
+	if err != nil {
+		return nil, err
+	}
+
+	return &router, nil
+}
+
+func networkingRouterV2StateRefreshFunc(ctx context.Context, client *gophercloud.ServiceClient, id string) retry.StateRefreshFunc {
+	return func() (any, string, error) {
+		router, err := networkingRouterV2Get(ctx, client, id)
+		if err != nil {
+			if gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+				return router, "DELETED", nil
+			}
+
+			return nil, "", fmt.Errorf("unable to get Router %s: %w", id, err)
+		}
+
+		return router, router.Status, nil
+	}
+}
+
+func expandNetworkingRouterV2GatewayInfo(d *schema.ResourceData) *GatewayInfo {
+	networkID := d.Get("external_network_id").(string)
+	if networkID == "" {
+		return nil
+	}
+
+	gatewayInfo := &GatewayInfo{
+		NetworkID:      networkID,
+		ExternalPortID: d.Get("external_port_id").(string),
+		QoSPolicyID:    d.Get("qos_policy_id").(string),
+	}
+
+	if v, ok := getOkExists(d, "enable_snat"); ok {
+		snat := v.(bool)
+		gatewayInfo.EnableSNAT = &snat
+	}
+
+	for _, v := range d.Get("external_fixed_ip").([]any) {
+		if v, ok := v.(map[string]any); ok {
+			gatewayInfo.ExternalFixedIPs = append(gatewayInfo.ExternalFixedIPs, routers.ExternalFixedIP{
+				SubnetID:  v["subnet_id"].(string),
+				IPAddress: v["ip_address"].(string),
+			})
+		}
+	}
+
+	return gatewayInfo
+}
@@ -1,6 +1,10 @@
 package sci
 
 import (
+	"context"
+	"fmt"
+	"regexp"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/sapcc/gophercloud-sapcc/v2/billing/masterdata/projects"
 )
@@ -41,6 +45,47 @@ func billingProjectExpandCostObject(raw any) projects.CostObject {
 	return co
 }
 
+// billingCostObjectNameRegex maps the cost object types to the format of
+// their names, which follows the SAP field lengths.
+var billingCostObjectNameRegex = map[string]string{
+	"IO":  `^[A-Z0-9]{1,12}$`,
+	"CC":  `^[A-Z0-9]{1,10}$`,
+	"WBS": `^[A-Z0-9][A-Z0-9.\-]{0,23}$`,
+	"SO":  `^[0-9]{1,10}$`,
+}
+
+// billingValidateCostObject validates the cost object name against its type.
+// Used by the project and the domain masterdata resources.
+func billingValidateCostObject(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	if !d.NewValueKnown("cost_object") {
+		return nil
+	}
+	v, ok := d.Get("cost_object").([]any)
+	if !ok || len(v) == 0 || v[0] == nil {
+		return nil
+	}
+	co := v[0].(map[string]any)
+	if v, ok := co["inherited"].(bool); ok && v {
+		return nil
+	}
+
+	name, _ := co["name"].(string)
+	typ, _ := co["type"].(string)
+	if name == "" || typ == "" {
+		return nil
+	}
+
+	re, ok := billingCostObjectNameRegex[typ]
+	if !ok {
+		return nil
+	}
+	if !regexp.MustCompile(re).MatchString(name) {
+		return fmt.Errorf("invalid %s cost object name %q: must match %s", typ, name, re)
+	}
+
+	return nil
+}
+
 // replaceEmptyString is a helper function to replace empty string fields with
 // another field.
 func replaceEmptyString(d *schema.ResourceData, field string, b string) string {
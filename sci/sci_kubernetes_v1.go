@@ -1,13 +1,17 @@
 package sci
 
 import (
+	"bytes"
 	"context"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"net/url"
 	"reflect"
 	"regexp"
 	"strings"
@@ -15,8 +19,10 @@ import (
 
 	"github.com/go-openapi/strfmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/sapcc/kubernikus/pkg/api/client/operations"
 	"github.com/sapcc/kubernikus/pkg/api/models"
+	"golang.org/x/crypto/ssh"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api/v1"
 	"sigs.k8s.io/yaml"
 )
@@ -24,6 +30,7 @@ import (
 const (
 	klusterNameRegex = "^[a-z][-a-z0-9]{0,18}[a-z0-9]?$"
 	poolNameRegex    = "^[a-z][-\\.a-z0-9]{0,18}[a-z0-9]?$"
+	dnsLabelRegex    = "^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$"
 )
 
 func kubernikusValidateClusterName(v any, k string) (ws []string, errors []error) {
@@ -46,6 +53,48 @@ func kubernikusValidatePoolName(v any, k string) (ws []string, errors []error) {
 	return
 }
 
+// kubernikusValidateDNSDomain validates the cluster DNS domain, which must
+// consist of lowercase RFC 1123 labels and must not have a trailing dot.
+func kubernikusValidateDNSDomain(v any, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value == "" {
+		return
+	}
+
+	if strings.HasSuffix(value, ".") {
+		errors = append(errors, fmt.Errorf("%q must not end with a dot: %s", k, value))
+		return
+	}
+
+	if len(value) > 253 {
+		errors = append(errors, fmt.Errorf("%q must be at most 253 characters long: %s", k, value))
+		return
+	}
+
+	re := regexp.MustCompile(dnsLabelRegex)
+	for _, label := range strings.Split(value, ".") {
+		if !re.MatchString(label) {
+			errors = append(errors,
+				fmt.Errorf("%q must consist of dot separated labels of 1 to 63 lowercase letters, numbers and hyphens, starting and ending with a letter or a number: %s", k, value))
+			return
+		}
+	}
+	return
+}
+
+func kubernikusValidateSSHPublicKey(v any, k string) ([]string, []error) {
+	rest := []byte(v.(string))
+	for len(bytes.TrimSpace(rest)) > 0 {
+		var err error
+		_, _, _, rest, err = ssh.ParseAuthorizedKey(rest)
+		if err != nil {
+			return nil, []error{fmt.Errorf("invalid %s: %s", k, err)}
+		}
+	}
+
+	return nil, nil
+}
+
 func kubernikusValidateAuthConf(v any, k string) ([]string, []error) {
 	if v == nil {
 		return nil, nil
@@ -63,6 +112,125 @@ func kubernikusValidateAuthConf(v any, k string) ([]string, []error) {
 	return nil, nil
 }
 
+// kubernikusValidateNodePoolNamesV1 rejects duplicate node pool names and
+// names without the node_pools_name_prefix at plan time, before any long
+// running cluster update is started.
+func kubernikusValidateNodePoolNamesV1(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	var names []string
+	prefix := d.Get("node_pools_name_prefix").(string)
+
+	for _, v := range d.Get("node_pools").([]any) {
+		v, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		// the name may be unknown during the plan
+		name, _ := v["name"].(string)
+		if name == "" {
+			continue
+		}
+
+		if strSliceContains(names, name) {
+			return fmt.Errorf("duplicate node pool name found: %s", name)
+		}
+		if !strings.HasPrefix(name, prefix) {
+			return fmt.Errorf("node pool name %s doesn't match the node_pools_name_prefix %s", name, prefix)
+		}
+		names = append(names, name)
+	}
+
+	return nil
+}
+
+// kubernikusValidateCIDRChangeV1 rejects changes of the cluster and service
+// CIDRs of an existing cluster, which would recreate the cluster, unless the
+// allow_cidr_change flag is set.
+func kubernikusValidateCIDRChangeV1(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	if d.Id() == "" || d.Get("allow_cidr_change").(bool) {
+		return nil
+	}
+
+	for _, key := range []string{"cluster_cidr", "service_cidr"} {
+		// unknown values can't be compared during the plan
+		if !d.HasChange(key) || !d.NewValueKnown(key) {
+			continue
+		}
+
+		o, n := d.GetChange(key)
+		// the service CIDR is generated, when it is not set
+		if key == "service_cidr" && n.(string) == "" {
+			continue
+		}
+
+		return fmt.Errorf("changing %s of the %s cluster from %q to %q recreates the cluster and all its workloads are lost, "+
+			"set allow_cidr_change to true to proceed", key, d.Id(), o, n)
+	}
+
+	return nil
+}
+
+// kubernikusUpgradeNodePoolsV1 returns the existing node pools, which are
+// rolled by a version upgrade, in the expected order.
+func kubernikusUpgradeNodePoolsV1(oldPools, newPools []any) []string {
+	var existing []string
+	for _, v := range oldPools {
+		if v, ok := v.(map[string]any); ok {
+			existing = append(existing, v["name"].(string))
+		}
+	}
+
+	// new node pools are created with the new version, pools without nodes
+	// have nothing to roll and pools, which don't allow the replacement of
+	// nodes, aren't upgraded automatically
+	pools := []string{}
+	for _, v := range newPools {
+		v, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _ := v["name"].(string)
+		size, _ := v["size"].(int)
+		if size == 0 || !strSliceContains(existing, name) {
+			continue
+		}
+		if c, ok := v["config"].([]any); ok && len(c) > 0 {
+			if c, ok := c[0].(map[string]any); ok {
+				if allow, ok := c["allow_replace"].(bool); ok && !allow {
+					continue
+				}
+			}
+		}
+		pools = append(pools, name)
+	}
+
+	return pools
+}
+
+// kubernikusPlanUpgradeNodePoolsV1 annotates the plan of a version upgrade
+// with the existing node pools, which will be rolled in the listed order.
+func kubernikusPlanUpgradeNodePoolsV1(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	o, n := d.GetChange("node_pools")
+	return d.SetNew("upgrade_node_pools", kubernikusUpgradeNodePoolsV1(o.([]any), n.([]any)))
+}
+
+// kubernikusNormalizeURL normalizes the URLs reported in the cluster status,
+// e.g. the Wormhole server endpoint, which is a plain URL.
+func kubernikusNormalizeURL(v string) string {
+	if v == "" {
+		return ""
+	}
+
+	u, err := url.Parse(v)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		log.Printf("[DEBUG] Kubernikus returned an invalid URL %q: %v", v, err)
+		return v
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+
+	return u.String()
+}
+
 func kubernikusFlattenOpenstackSpecV1(spec *models.OpenstackSpec) []map[string]any {
 	if spec == (&models.OpenstackSpec{}) {
 		return nil
@@ -77,6 +245,25 @@ func kubernikusFlattenOpenstackSpecV1(spec *models.OpenstackSpec) []map[string]a
 	}}
 }
 
+// kubernikusFilterNodePoolsV1 splits the node pools into the pools managed by
+// the resource, i.e. matching the name prefix, and all the other pools.
+func kubernikusFilterNodePoolsV1(nodePools []models.NodePool, prefix string) ([]models.NodePool, []models.NodePool) {
+	if prefix == "" {
+		return nodePools, nil
+	}
+
+	var managed, unmanaged []models.NodePool
+	for _, p := range nodePools {
+		if strings.HasPrefix(p.Name, prefix) {
+			managed = append(managed, p)
+		} else {
+			unmanaged = append(unmanaged, p)
+		}
+	}
+
+	return managed, unmanaged
+}
+
 func kubernikusFlattenNodePoolsV1(nodePools []models.NodePool) []map[string]any {
 	res := make([]map[string]any, 0, len(nodePools))
 	for _, p := range nodePools {
@@ -132,7 +319,97 @@ func kubernikusExpandOpenstackSpecV1(raw any) *models.OpenstackSpec {
 	return nil
 }
 
-func kubernikusExpandNodePoolsV1(raw any) ([]models.NodePool, error) {
+// kubernikusNodePoolDefaultsV1 holds the cluster-wide node pool labels and
+// taints, which are merged into every node pool.
+type kubernikusNodePoolDefaultsV1 struct {
+	labels []string
+	taints []string
+}
+
+func kubernikusExpandNodePoolDefaultsV1(labels, taints any) kubernikusNodePoolDefaultsV1 {
+	return kubernikusNodePoolDefaultsV1{
+		labels: expandToStringSlice(labels.([]any)),
+		taints: expandToStringSlice(taints.([]any)),
+	}
+}
+
+// kubernikusNodeLabelKey returns the key of a "key=value" label.
+func kubernikusNodeLabelKey(label string) string {
+	key, _, _ := strings.Cut(label, "=")
+	return key
+}
+
+// kubernikusNodeTaintKey returns the key and the effect of a
+// "key=value:effect" taint, which together identify a taint.
+func kubernikusNodeTaintKey(taint string) string {
+	key, _, _ := strings.Cut(taint, "=")
+	key, _, _ = strings.Cut(key, ":")
+	_, effect, _ := strings.Cut(taint, ":")
+	return key + ":" + effect
+}
+
+// kubernikusMergeNodePoolDefaultsV1 merges the defaults into the values,
+// unless the values already contain an entry with the same key.
+func kubernikusMergeNodePoolDefaultsV1(values, defaults []string, keyFunc func(string) string) []string {
+	if len(defaults) == 0 {
+		return values
+	}
+
+	keys := make([]string, len(values))
+	for i, v := range values {
+		keys[i] = keyFunc(v)
+	}
+
+	var res []string
+	for _, v := range defaults {
+		if !sliceContains(keys, keyFunc(v)) {
+			res = append(res, v)
+		}
+	}
+
+	return append(res, values...)
+}
+
+// kubernikusStripNodePoolDefaultsV1 removes the merged defaults from the
+// flattened node pools, unless they were explicitly set on a node pool.
+func kubernikusStripNodePoolDefaultsV1(nodePools []map[string]any, rawState any, defaults kubernikusNodePoolDefaultsV1) {
+	if len(defaults.labels) == 0 && len(defaults.taints) == 0 {
+		return
+	}
+
+	statePools := make(map[string]map[string]any)
+	if v, ok := rawState.([]any); ok {
+		for _, v := range v {
+			if v, ok := v.(map[string]any); ok {
+				statePools[v["name"].(string)] = v
+			}
+		}
+	}
+
+	strip := func(values []string, defaults []string, configured any) []string {
+		var explicit []string
+		if v, ok := configured.([]any); ok {
+			explicit = expandToStringSlice(v)
+		}
+
+		var res []string
+		for _, v := range values {
+			if sliceContains(defaults, v) && !sliceContains(explicit, v) {
+				continue
+			}
+			res = append(res, v)
+		}
+		return res
+	}
+
+	for _, p := range nodePools {
+		statePool := statePools[p["name"].(string)]
+		p["labels"] = strip(p["labels"].([]string), defaults.labels, statePool["labels"])
+		p["taints"] = strip(p["taints"].([]string), defaults.taints, statePool["taints"])
+	}
+}
+
+func kubernikusExpandNodePoolsV1(raw any, defaults kubernikusNodePoolDefaultsV1) ([]models.NodePool, error) {
 	var names []string
 
 	if raw != nil {
@@ -165,9 +442,11 @@ func kubernikusExpandNodePoolsV1(raw any) ([]models.NodePool, error) {
 					if v, ok := v["taints"]; ok {
 						p.Taints = expandToStringSlice(v.([]any))
 					}
+					p.Taints = kubernikusMergeNodePoolDefaultsV1(p.Taints, defaults.taints, kubernikusNodeTaintKey)
 					if v, ok := v["labels"]; ok {
 						p.Labels = expandToStringSlice(v.([]any))
 					}
+					p.Labels = kubernikusMergeNodePoolDefaultsV1(p.Labels, defaults.labels, kubernikusNodeLabelKey)
 					if v, ok := v["custom_root_disk_size"]; ok {
 						p.CustomRootDiskSize = int64(v.(int))
 					}
@@ -193,7 +472,7 @@ func kubernikusWaitForClusterV1(ctx context.Context, klient *kubernikus, name st
 	stateConf := &retry.StateChangeConf{
 		Target:     []string{target},
 		Pending:    pending,
-		Refresh:    kubernikusKlusterV1GetPhase(klient, target, name),
+		Refresh:    kubernikusKlusterV1GetPhase(ctx, klient, target, name),
 		Timeout:    timeout,
 		Delay:      1 * time.Second,
 		MinTimeout: 1 * time.Second,
@@ -201,7 +480,7 @@ func kubernikusWaitForClusterV1(ctx context.Context, klient *kubernikus, name st
 
 	_, err := stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		if e, ok := err.(*operations.ShowClusterDefault); ok && target == "Terminated" && e.Payload.Message == "Not found" {
+		if target == "Terminated" && kubernikusIsNotFound(err) {
 			return nil
 		}
 	}
@@ -209,15 +488,49 @@ func kubernikusWaitForClusterV1(ctx context.Context, klient *kubernikus, name st
 	return err
 }
 
-func kubernikusKlusterV1GetPhase(klient *kubernikus, target string, name string) retry.StateRefreshFunc {
+// kubernikusWaitForDashboardV1 waits until the cluster status reports the
+// dashboard URL, i.e. the dashboard addon was deployed. Kubernikus reports no
+// status for the dex addon.
+func kubernikusWaitForDashboardV1(ctx context.Context, klient *kubernikus, name string, timeout time.Duration) error {
+	log.Printf("[DEBUG] Waiting for the dashboard of %s cluster to become ready.", name)
+
+	stateConf := &retry.StateChangeConf{
+		Target:  []string{"Ready"},
+		Pending: []string{"Pending"},
+		Refresh: func() (any, string, error) {
+			result, err := klient.ShowCluster(operations.NewShowClusterParams().WithContext(ctx).WithName(name), klient.authFunc())
+			if err != nil {
+				return nil, "", err
+			}
+			if result.Payload.Status.Dashboard == "" {
+				return result.Payload, "Pending", nil
+			}
+			return result.Payload, "Ready", nil
+		},
+		Timeout:    timeout,
+		Delay:      1 * time.Second,
+		MinTimeout: 2 * time.Second,
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+// kubernikusMutexKeyV1 returns the MutexKV key, which serializes the
+// operations on the same cluster.
+func kubernikusMutexKeyV1(region, name string) string {
+	return fmt.Sprintf("kubernikus/%s/%s", region, name)
+}
+
+func kubernikusKlusterV1GetPhase(ctx context.Context, klient *kubernikus, target string, name string) retry.StateRefreshFunc {
 	return func() (any, string, error) {
-		result, err := klient.ShowCluster(operations.NewShowClusterParams().WithName(name), klient.authFunc())
+		result, err := klient.ShowCluster(operations.NewShowClusterParams().WithContext(ctx).WithName(name), klient.authFunc())
 		if err != nil {
 			return nil, "", err
 		}
 
 		if target != "Terminated" {
-			events, err := klient.GetClusterEvents(operations.NewGetClusterEventsParams().WithName(name), klient.authFunc())
+			events, err := klient.GetClusterEvents(operations.NewGetClusterEventsParams().WithContext(ctx).WithName(name), klient.authFunc())
 			if err != nil {
 				return nil, "", err
 			}
@@ -231,43 +544,44 @@ func kubernikusKlusterV1GetPhase(klient *kubernikus, target string, name string)
 				}
 			}
 
-			for _, a := range result.Payload.Spec.NodePools {
-				// workaround for the upgrade status race condition
-				if result.Payload.Status.Phase == models.KlusterPhaseRunning &&
-					result.Payload.Spec.Version != result.Payload.Status.ApiserverVersion {
-					return result.Payload, string(models.KlusterPhaseUpgrading), nil
-				}
+			return result.Payload, kubernikusKlusterV1Phase(result.Payload), nil
+		}
 
-				for _, s := range result.Payload.Status.NodePools {
-					if a.Name == s.Name {
-						// sometimes status size doesn't reflect the actual size, therefore we use "a.Size"
-						if a.Size != s.Healthy {
-							return result.Payload, "Pending", nil
-						}
-					}
-				}
-			}
+		return result.Payload, string(result.Payload.Status.Phase), nil
+	}
+}
 
-			if len(result.Payload.Spec.NodePools) != len(result.Payload.Status.NodePools) {
-				return result.Payload, "Pending", nil
+// kubernikusKlusterV1Phase returns the phase of the cluster, which is reported
+// as "Upgrading" or "Pending" until the API server and all node pools have
+// caught up with the cluster spec.
+func kubernikusKlusterV1Phase(kluster *models.Kluster) string {
+	for _, a := range kluster.Spec.NodePools {
+		// workaround for the upgrade status race condition
+		if kluster.Status.Phase == models.KlusterPhaseRunning &&
+			kluster.Spec.Version != kluster.Status.ApiserverVersion {
+			return string(models.KlusterPhaseUpgrading)
+		}
+
+		for _, s := range kluster.Status.NodePools {
+			if a.Name == s.Name {
+				// sometimes status size doesn't reflect the actual size, therefore we use "a.Size"
+				if a.Size != s.Healthy {
+					return "Pending"
+				}
 			}
 		}
+	}
 
-		return result.Payload, string(result.Payload.Status.Phase), nil
+	if len(kluster.Spec.NodePools) != len(kluster.Status.NodePools) {
+		return "Pending"
 	}
+
+	return string(kluster.Status.Phase)
 }
 
-func kubernikusUpdateNodePoolsV1(ctx context.Context, klient *kubernikus, cluster *models.Kluster, oldNodePoolsRaw, newNodePoolsRaw any, target string, pending []string, timeout time.Duration) error {
+func kubernikusUpdateNodePoolsV1(ctx context.Context, klient *kubernikus, cluster *models.Kluster, changed []string, prefix string, oldNodePools, newNodePools, unmanagedNodePools []models.NodePool, fastDelete bool, target string, pending []string, deadline time.Time) error {
 	var poolsToKeep []models.NodePool
 	var poolsToDelete []models.NodePool
-	oldNodePools, err := kubernikusExpandNodePoolsV1(oldNodePoolsRaw)
-	if err != nil {
-		return err
-	}
-	newNodePools, err := kubernikusExpandNodePoolsV1(newNodePoolsRaw)
-	if err != nil {
-		return err
-	}
 
 	pretty, err := json.MarshalIndent(oldNodePools, "", "  ")
 	if err != nil {
@@ -308,26 +622,37 @@ func kubernikusUpdateNodePoolsV1(ctx context.Context, klient *kubernikus, cluste
 	pretty, _ = json.MarshalIndent(poolsToDelete, "", "  ")
 	log.Printf("[DEBUG] Downscale node pools: %s", string(pretty))
 
-	if len(poolsToDelete) > 0 {
+	if len(poolsToDelete) > 0 && !fastDelete {
 		// downscale
-		cluster.Spec.NodePools = append(poolsToKeep, poolsToDelete...)
-		err = kubernikusUpdateAndWait(ctx, klient, cluster, target, pending, timeout)
+		if err := kubernikusCheckCanceled(ctx, "downscaling the removed node pools"); err != nil {
+			return err
+		}
+		cluster.Spec.NodePools = append(append(poolsToKeep, poolsToDelete...), unmanagedNodePools...)
+		err = kubernikusUpdateAndWait(ctx, klient, cluster, changed, prefix, target, pending, deadline)
 		if err != nil {
 			return err
 		}
+		_, unmanagedNodePools = kubernikusFilterNodePoolsV1(cluster.Spec.NodePools, prefix)
 	}
 
 	// delete old
-	cluster.Spec.NodePools = poolsToKeep
-	err = kubernikusUpdateAndWait(ctx, klient, cluster, target, pending, timeout)
+	if err := kubernikusCheckCanceled(ctx, "deleting the removed node pools"); err != nil {
+		return err
+	}
+	cluster.Spec.NodePools = append(poolsToKeep, unmanagedNodePools...)
+	err = kubernikusUpdateAndWait(ctx, klient, cluster, changed, prefix, target, pending, deadline)
 	if err != nil {
 		return err
 	}
+	_, unmanagedNodePools = kubernikusFilterNodePoolsV1(cluster.Spec.NodePools, prefix)
 
 	if !reflect.DeepEqual(poolsToKeep, newNodePools) {
 		// create new
-		cluster.Spec.NodePools = newNodePools
-		err = kubernikusUpdateAndWait(ctx, klient, cluster, target, pending, timeout)
+		if err := kubernikusCheckCanceled(ctx, "creating the new node pools"); err != nil {
+			return err
+		}
+		cluster.Spec.NodePools = append(newNodePools, unmanagedNodePools...)
+		err = kubernikusUpdateAndWait(ctx, klient, cluster, changed, prefix, target, pending, deadline)
 		if err != nil {
 			return err
 		}
@@ -336,98 +661,301 @@ func kubernikusUpdateNodePoolsV1(ctx context.Context, klient *kubernikus, cluste
 	return nil
 }
 
-func kubernikusHandleErrorV1(msg string, err error) error {
+// kubernikusCheckCanceled returns an error, when the context was canceled
+// before the next node pool update stage.
+func kubernikusCheckCanceled(ctx context.Context, stage string) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("node pool update was aborted before %s: %w", stage, err)
+	}
+	return nil
+}
+
+// kubernikusIsNotFound reports whether a Kubernikus operation failed, because
+// the cluster doesn't exist.
+func kubernikusIsNotFound(err error) bool {
+	var codeErr interface{ IsCode(int) bool }
+	if errors.As(err, &codeErr) && codeErr.IsCode(http.StatusNotFound) {
+		return true
+	}
+
+	// the default responses may also carry the status in the payload only
+	var payloadErr interface{ GetPayload() *models.Error }
+	if errors.As(err, &payloadErr) {
+		if p := payloadErr.GetPayload(); p != nil {
+			return p.Code == http.StatusNotFound || strings.EqualFold(p.Message, "not found")
+		}
+	}
+
+	return false
+}
+
+// kubernikusHandleErrorV1 adds the request ID of the last Kubernikus API
+// response to the error, so it can be referenced in support requests.
+func kubernikusHandleErrorV1(klient *kubernikus, msg string, err error) error {
 	switch res := err.(type) {
 	case *operations.TerminateClusterDefault:
-		return fmt.Errorf("%s: %s", msg, res.Payload.Message)
+		return fmt.Errorf("%s: %s", msg, withRequestID(res.Payload.Message, klient.lastRequestID()))
 	case error:
-		return fmt.Errorf("%s: %v", msg, err)
+		return fmt.Errorf("%s: %s", msg, withRequestID(err.Error(), klient.lastRequestID()))
 	}
 	return err
 }
 
-func kubernikusUpdateAndWait(ctx context.Context, klient *kubernikus, cluster *models.Kluster, target string, pending []string, timeout time.Duration) error {
-	_, err := klient.UpdateCluster(operations.NewUpdateClusterParams().WithName(cluster.Name).WithBody(cluster), klient.authFunc())
-	if err != nil {
-		return kubernikusHandleErrorV1("Error updating cluster", err)
+// kubernikusUpdateAndWait updates the cluster and waits for the target phase
+// until the deadline, which is shared by all stages of an update. A
+// conflicting concurrent update is resolved by rebasing the changed spec
+// fields and the managed node pools onto the current cluster spec.
+func kubernikusUpdateAndWait(ctx context.Context, klient *kubernikus, cluster *models.Kluster, changed []string, prefix string, target string, pending []string, deadline time.Time) error {
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	for {
+		_, err := klient.UpdateCluster(operations.NewUpdateClusterParams().WithContext(ctx).WithName(cluster.Name).WithBody(cluster), klient.authFunc())
+		if err == nil {
+			break
+		}
+
+		if e, ok := err.(*operations.UpdateClusterDefault); !ok || !e.IsCode(http.StatusConflict) {
+			return kubernikusHandleErrorV1(klient, "Error updating cluster", err)
+		}
+
+		log.Printf("[DEBUG] Conflict updating %s cluster, waiting for the %s phase before retrying", cluster.Name, target)
+		err = kubernikusWaitForClusterV1(ctx, klient, cluster.Name, target, pending, time.Until(deadline))
+		if err != nil {
+			return kubernikusHandleErrorV1(klient, "Error waiting for cluster to settle after a conflict", err)
+		}
+
+		// don't overwrite the changes of the concurrent update
+		result, err := klient.ShowCluster(operations.NewShowClusterParams().WithContext(ctx).WithName(cluster.Name), klient.authFunc())
+		if err != nil {
+			return kubernikusHandleErrorV1(klient, "Error reading Kubernikus cluster", err)
+		}
+		cluster.Spec = kubernikusRebaseSpecV1(result.Payload.Spec, cluster.Spec, changed, prefix)
 	}
 
-	err = kubernikusWaitForClusterV1(ctx, klient, cluster.Name, target, pending, timeout)
+	err := kubernikusWaitForClusterV1(ctx, klient, cluster.Name, target, pending, time.Until(deadline))
 	if err != nil {
-		return kubernikusHandleErrorV1("Error waiting for cluster node pools Running state", err)
+		return kubernikusHandleErrorV1(klient, "Error waiting for cluster node pools Running state", err)
 	}
 
 	return nil
 }
 
-func getCredentials(klient *kubernikus, name string, creds string) (string, []map[string]string, error) {
+// kubernikusSpecFieldsV1 lists the attributes, which are sent in the spec of
+// a cluster update.
+var kubernikusSpecFieldsV1 = []string{
+	"audit",
+	"ssh_public_key",
+	"backup",
+	"dex",
+	"oidc",
+	"authentication_configuration",
+	"dashboard",
+	"version",
+	"openstack",
+}
+
+// kubernikusRebaseSpecV1 copies the changed fields and the managed node pools
+// of the desired spec onto the current spec. The node pools, which don't match
+// the prefix, are kept as they are now.
+func kubernikusRebaseSpecV1(current, desired models.KlusterSpec, changed []string, prefix string) models.KlusterSpec {
+	for _, key := range changed {
+		switch key {
+		case "audit":
+			current.Audit = desired.Audit
+		case "ssh_public_key":
+			current.SSHPublicKey = desired.SSHPublicKey
+		case "backup":
+			current.Backup = desired.Backup
+		case "dex":
+			current.Dex = desired.Dex
+		case "oidc":
+			current.Oidc = desired.Oidc
+		case "authentication_configuration":
+			current.AuthenticationConfiguration = desired.AuthenticationConfiguration
+		case "dashboard":
+			current.Dashboard = desired.Dashboard
+		case "version":
+			current.Version = desired.Version
+		case "openstack":
+			current.Openstack.SecurityGroupName = desired.Openstack.SecurityGroupName
+		}
+	}
+
+	managed, _ := kubernikusFilterNodePoolsV1(desired.NodePools, prefix)
+	_, unmanaged := kubernikusFilterNodePoolsV1(current.NodePools, prefix)
+	current.NodePools = append(managed, unmanaged...)
+
+	return current
+}
+
+func getCredentials(klient *kubernikus, name string, creds string) (string, []map[string]any, error) {
 	var err error
-	var kubeConfig []map[string]string
-	var crt *x509.Certificate
+	var contexts []map[string]any
+	var crts []*x509.Certificate
 
 	if creds == "" {
-		creds, kubeConfig, err = downloadCredentials(klient, name)
+		creds, contexts, err = downloadCredentials(klient, name)
 		if err != nil {
 			return "", nil, err
 		}
 	} else {
-		kubeConfig, crt, err = flattenKubernetesClusterKubeConfig(creds)
+		contexts, crts, err = flattenKubernetesClusterKubeConfig(creds)
 		if err != nil {
 			return "", nil, err
 		}
 
-		// Check so that the certificate is valid now
+		// Check so that all the certificates are valid now
 		now := time.Now()
-		if now.Before(crt.NotBefore) || now.After(crt.NotAfter) {
-			log.Printf("[DEBUG] The Kubernikus certificate is not valid")
-			creds, kubeConfig, err = downloadCredentials(klient, name)
-			if err != nil {
-				return "", nil, err
+		for _, crt := range crts {
+			if now.Before(crt.NotBefore) || now.After(crt.NotAfter) {
+				log.Printf("[DEBUG] The Kubernikus certificate %q is only valid from %s to %s", crt.Subject, crt.NotBefore, crt.NotAfter)
+				creds, contexts, err = downloadCredentials(klient, name)
+				if err != nil {
+					return "", nil, err
+				}
+				break
 			}
 		}
 	}
 
-	return creds, kubeConfig, nil
+	return creds, contexts, nil
 }
 
-func flattenKubernetesClusterKubeConfig(creds string) ([]map[string]string, *x509.Certificate, error) {
+// flattenKubernetesClusterKubeConfig returns the credentials of every context
+// in the kubeconfig. A kubeconfig without contexts is flattened into a single
+// unnamed context, using its first cluster and user.
+func flattenKubernetesClusterKubeConfig(creds string) ([]map[string]any, []*x509.Certificate, error) {
 	var cfg clientcmdapi.Config
-	values := make(map[string]string)
-	var crt *x509.Certificate
 
 	err := yaml.Unmarshal([]byte(creds), &cfg)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to unmarshal Kubernikus kubeconfig: %s", err)
 	}
 
-	for _, v := range cfg.Clusters {
-		values["host"] = v.Cluster.Server
-		values["cluster_ca_certificate"] = base64.StdEncoding.EncodeToString(v.Cluster.CertificateAuthorityData)
+	contexts := cfg.Contexts
+	if len(contexts) == 0 && len(cfg.Clusters) > 0 && len(cfg.AuthInfos) > 0 {
+		contexts = []clientcmdapi.NamedContext{{
+			Context: clientcmdapi.Context{
+				Cluster:  cfg.Clusters[0].Name,
+				AuthInfo: cfg.AuthInfos[0].Name,
+			},
+		}}
 	}
 
-	for _, v := range cfg.AuthInfos {
-		values["username"] = v.Name
-		values["client_certificate"] = base64.StdEncoding.EncodeToString(v.AuthInfo.ClientCertificateData)
-		values["client_key"] = base64.StdEncoding.EncodeToString(v.AuthInfo.ClientKeyData)
+	var res []map[string]any
+	var crts []*x509.Certificate
+	for _, c := range contexts {
+		values := map[string]any{
+			"name":     c.Name,
+			"current":  c.Name != "" && c.Name == cfg.CurrentContext,
+			"username": c.Context.AuthInfo,
+		}
 
-		// parse certificate date
-		pem, _ := pem.Decode(v.AuthInfo.ClientCertificateData)
-		if pem == nil {
-			return nil, nil, fmt.Errorf("failed to decode PEM")
+		for _, v := range cfg.Clusters {
+			if v.Name == c.Context.Cluster {
+				values["host"] = v.Cluster.Server
+				values["cluster_ca_certificate"] = base64.StdEncoding.EncodeToString(v.Cluster.CertificateAuthorityData)
+				break
+			}
 		}
-		crt, err = x509.ParseCertificate(pem.Bytes)
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to parse Kubernikus certificate %s", err)
+
+		for _, v := range cfg.AuthInfos {
+			if v.Name != c.Context.AuthInfo {
+				continue
+			}
+
+			values["token"] = v.AuthInfo.Token
+			values["exec"] = kubernikusFlattenExecConfigV1(v.AuthInfo.Exec)
+
+			// token and exec based users have no client certificate
+			if len(v.AuthInfo.ClientCertificateData) == 0 {
+				break
+			}
+
+			values["client_certificate"] = base64.StdEncoding.EncodeToString(v.AuthInfo.ClientCertificateData)
+			values["client_key"] = base64.StdEncoding.EncodeToString(v.AuthInfo.ClientKeyData)
+
+			// parse certificate date
+			crt, err := kubernikusParseCertificate(v.AuthInfo.ClientCertificateData)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid client certificate of the %q user in the %q context: %w", v.Name, c.Name, err)
+			}
+			values["not_before"] = crt.NotBefore.Format(time.RFC3339)
+			values["not_after"] = crt.NotAfter.Format(time.RFC3339)
+			crts = append(crts, crt)
+			break
+		}
+
+		res = append(res, values)
+	}
+
+	if len(res) == 0 {
+		return nil, nil, fmt.Errorf("failed to get Kubernikus kubeconfig credentials: the kubeconfig has no contexts")
+	}
+
+	return res, crts, nil
+}
+
+func kubernikusFlattenExecConfigV1(exec *clientcmdapi.ExecConfig) []map[string]any {
+	if exec == nil {
+		return nil
+	}
+
+	env := make(map[string]string, len(exec.Env))
+	for _, e := range exec.Env {
+		env[e.Name] = e.Value
+	}
+
+	return []map[string]any{{
+		"api_version": exec.APIVersion,
+		"command":     exec.Command,
+		"args":        exec.Args,
+		"env":         env,
+	}}
+}
+
+// kubernikusParseCertificate parses the first PEM encoded certificate.
+func kubernikusParseCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PEM data")
+	}
+	if block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("unexpected PEM block type %q, expected CERTIFICATE", block.Type)
+	}
+
+	crt, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Kubernikus certificate: %w", err)
+	}
+
+	return crt, nil
+}
+
+// kubernikusFlattenKubeConfigV1 returns the credentials of the current
+// context, or of the first context, when no current context is set.
+func kubernikusFlattenKubeConfigV1(contexts []map[string]any) []map[string]any {
+	if len(contexts) == 0 {
+		return nil
+	}
+
+	current := contexts[0]
+	for _, c := range contexts {
+		if c["current"].(bool) {
+			current = c
+			break
 		}
-		values["not_before"] = crt.NotBefore.Format(time.RFC3339)
-		values["not_after"] = crt.NotAfter.Format(time.RFC3339)
 	}
 
-	if crt == nil {
-		return nil, nil, fmt.Errorf("failed to get Kubernikus kubeconfig credentials %s", err)
+	values := make(map[string]any, len(current))
+	for k, v := range current {
+		if k != "name" && k != "current" {
+			values[k] = v
+		}
 	}
 
-	return []map[string]string{values}, crt, nil
+	return []map[string]any{values}
 }
 
 func kubernikusFlattenOIDCV1(oidc *models.OIDC) []map[string]string {
@@ -474,18 +1002,18 @@ func kubernikusExpandOIDCV1(raw any) *models.OIDC {
 	return nil
 }
 
-func downloadCredentials(klient *kubernikus, name string) (string, []map[string]string, error) {
+func downloadCredentials(klient *kubernikus, name string) (string, []map[string]any, error) {
 	credentials, err := klient.GetClusterCredentials(operations.NewGetClusterCredentialsParams().WithName(name), klient.authFunc())
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to download Kubernikus kubeconfig: %s", err)
 	}
 
-	kubeConfig, _, err := flattenKubernetesClusterKubeConfig(credentials.Payload.Kubeconfig)
+	contexts, _, err := flattenKubernetesClusterKubeConfig(credentials.Payload.Kubeconfig)
 	if err != nil {
 		return "", nil, err
 	}
 
-	return credentials.Payload.Kubeconfig, kubeConfig, nil
+	return credentials.Payload.Kubeconfig, contexts, nil
 }
 
 func verifySupportedKubernetesVersion(klient *kubernikus, version string) error {
@@ -0,0 +1,261 @@
+package sci
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	httptransport "github.com/go-openapi/runtime/client"
+	osClient "github.com/gophercloud/utils/v2/client"
+)
+
+const (
+	defaultRetryBaseDelay = 1 * time.Second
+	defaultRetryMaxDelay  = 30 * time.Second
+)
+
+// newOpenAPITransport returns a go-openapi transport for the SAP specific
+// services, which are not accessed through the gophercloud provider client.
+func newOpenAPITransport(c *Config, u *url.URL, service, svc string) *httptransport.Runtime {
+	transport := httptransport.New(u.Host, u.EscapedPath(), []string{u.Scheme})
+
+	rt := http.DefaultTransport.(*http.Transport).Clone()
+	rt.Proxy = c.proxyFunc()
+	rt.TLSClientConfig = c.tlsConfig(service)
+
+	transport.Transport = &retryRoundTripper{
+		rt:         rt,
+		svc:        svc,
+		maxRetries: c.MaxRetries,
+		baseDelay:  c.RetryBaseDelay,
+		maxDelay:   c.RetryMaxDelay,
+	}
+
+	if v, ok := c.OsClient.HTTPClient.Transport.(*osClient.RoundTripper); ok && v.Logger != nil {
+		// enable JSON debug for the service
+		transport.SetLogger(logger{svc})
+		transport.Debug = true
+	}
+
+	return transport
+}
+
+// tlsConfig returns the TLS configuration of the gophercloud provider client,
+// with the verification disabled, when the service is listed in
+// insecure_services.
+func (c *Config) tlsConfig(service string) *tls.Config {
+	config := &tls.Config{}
+	if v, ok := c.OsClient.HTTPClient.Transport.(*osClient.RoundTripper); ok {
+		if t, ok := v.Rt.(*http.Transport); ok && t.TLSClientConfig != nil {
+			config = t.TLSClientConfig.Clone()
+		}
+	}
+
+	if sliceContains(c.InsecureServices, service) {
+		log.Printf("[DEBUG] TLS verification is disabled for the %s service", service)
+		config.InsecureSkipVerify = true
+	}
+
+	return config
+}
+
+// requestIDHeaders are the response headers carrying the request ID, which is
+// asked for in support tickets.
+var requestIDHeaders = []string{"X-Openstack-Request-Id", "X-Request-Id"}
+
+// retryRoundTripper retries requests failing with a transient error using an
+// exponential backoff between baseDelay and maxDelay.
+type retryRoundTripper struct {
+	rt         http.RoundTripper
+	svc        string
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+
+	mu        sync.Mutex
+	requestID string
+}
+
+func (rrt *retryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	attempt := req
+	for retry := 0; ; retry++ {
+		resp, err := rrt.rt.RoundTrip(attempt)
+		rrt.recordRequestID(resp)
+		if retry >= rrt.maxRetries || !isRetryable(req, resp, err) {
+			return resp, err
+		}
+
+		// the RoundTripper must not modify the request, the retry is sent
+		// as a clone with a replayed body
+		attempt = req.Clone(req.Context())
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			attempt.Body = body
+		}
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		delay := rrt.backoff(retry)
+		if v, ok := retryAfter(resp, time.Now()); ok {
+			delay = min(v, rrt.retryMaxDelay())
+		}
+		log.Printf("[DEBUG] %s request %s %s failed, retrying in %s (%d/%d)", rrt.svc, req.Method, req.URL, delay, retry+1, rrt.maxRetries)
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (rrt *retryRoundTripper) recordRequestID(resp *http.Response) {
+	// a request without a response has no request ID, don't report the ID
+	// of an earlier response for it
+	var requestID string
+	if resp != nil {
+		for _, h := range requestIDHeaders {
+			if v := resp.Header.Get(h); v != "" {
+				requestID = v
+				break
+			}
+		}
+	}
+
+	rrt.mu.Lock()
+	rrt.requestID = requestID
+	rrt.mu.Unlock()
+}
+
+// lastRequestID returns the request ID of the last response.
+func (rrt *retryRoundTripper) lastRequestID() string {
+	rrt.mu.Lock()
+	defer rrt.mu.Unlock()
+	return rrt.requestID
+}
+
+// transportLastRequestID returns the request ID of the last response received
+// by a transport created with newOpenAPITransport.
+func transportLastRequestID(transport *httptransport.Runtime) string {
+	if rrt, ok := transport.Transport.(*retryRoundTripper); ok {
+		return rrt.lastRequestID()
+	}
+	return ""
+}
+
+// withRequestID appends the request ID to an error message.
+func withRequestID(msg, requestID string) string {
+	if requestID == "" {
+		return msg
+	}
+	return fmt.Sprintf("%s (request ID: %s)", msg, requestID)
+}
+
+func (rrt *retryRoundTripper) backoff(retry int) time.Duration {
+	return retryBackoff(rrt.baseDelay, rrt.maxDelay, retry)
+}
+
+func (rrt *retryRoundTripper) retryMaxDelay() time.Duration {
+	if rrt.maxDelay <= 0 {
+		return defaultRetryMaxDelay
+	}
+	return rrt.maxDelay
+}
+
+// retryAfter returns the delay requested by the Retry-After header of a rate
+// limited response, given either in seconds or as an HTTP date.
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+
+	return 0, false
+}
+
+// retryBackoff returns the exponential backoff delay for the given retry
+// attempt, falling back to the default delays when they are not configured.
+func retryBackoff(baseDelay, maxDelay time.Duration, retry int) time.Duration {
+	delay := baseDelay
+	if delay <= 0 {
+		delay = defaultRetryBaseDelay
+	}
+	if maxDelay <= 0 {
+		maxDelay = defaultRetryMaxDelay
+	}
+
+	for range retry {
+		delay *= 2
+		if delay >= maxDelay {
+			return maxDelay
+		}
+	}
+
+	return min(delay, maxDelay)
+}
+
+// isRetryable reports whether the request failed with a transient error.
+// Requests with non-idempotent methods, which may have been processed by the
+// server, are not retried.
+func isRetryable(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		return isIdempotent(req.Method) &&
+			!errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) && req.Context().Err() == nil
+	}
+
+	return isRetryableStatus(req.Method, resp.StatusCode)
+}
+
+// isRetryableStatus reports whether the HTTP status code is retried by the
+// transport. Server errors are retried only for idempotent methods. Client
+// errors, e.g. 409 or 422, are never retried, except for rate limited
+// requests, which were not processed and are retried regardless of the method
+// after the delay requested by the Retry-After header, capped at the maximum
+// retry delay.
+func isRetryableStatus(method string, code int) bool {
+	if code == http.StatusTooManyRequests {
+		return true
+	}
+
+	return code >= http.StatusInternalServerError && isIdempotent(method)
+}
+
+// isIdempotent reports whether repeating a request with the HTTP method has
+// the same effect as sending it once.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet,
+		http.MethodHead,
+		http.MethodOptions,
+		http.MethodPut,
+		http.MethodDelete:
+		return true
+	}
+
+	return false
+}
@@ -4,11 +4,15 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/validate"
 	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/tokens"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/sapcc/kubernikus/pkg/api/models"
 )
@@ -35,6 +39,98 @@ func GetRegion(d *schema.ResourceData, config *Config) string {
 	return config.Region
 }
 
+// regionNotSetMessage explains the region precedence, when no region could
+// be determined.
+const regionNotSetMessage = "no region is configured, set the resource \"region\", the provider \"region\", " +
+	"the OS_REGION_NAME environment variable or the \"region_name\" of the clouds.yaml cloud"
+
+// endpointLocatorError adds a hint about the region configuration to the
+// service catalog lookup errors, when no region was set, or lists the valid
+// regions, when the region doesn't provide the service.
+func endpointLocatorError(client *gophercloud.ProviderClient, err error, eo gophercloud.EndpointOpts) error {
+	if eo.Region == "" {
+		return fmt.Errorf("%w: %s", err, regionNotSetMessage)
+	}
+	if rerr := validateCatalogRegion(client, eo); rerr != nil {
+		return fmt.Errorf("%w: %s", err, rerr)
+	}
+	return err
+}
+
+// validateCatalogRegion verifies, that the region provides the service type
+// in the service catalog of the current token. Only the Identity v3 catalog
+// is checked.
+func validateCatalogRegion(client *gophercloud.ProviderClient, eo gophercloud.EndpointOpts) error {
+	var catalog *tokens.ServiceCatalog
+	var err error
+	switch result := client.GetAuthResult().(type) {
+	case tokens.CreateResult:
+		catalog, err = result.ExtractServiceCatalog()
+	case tokens.GetResult:
+		catalog, err = result.ExtractServiceCatalog()
+	default:
+		return nil
+	}
+	if err != nil || catalog == nil {
+		return nil
+	}
+
+	var regions []string
+	for _, entry := range catalog.Entries {
+		if entry.Type != eo.Type {
+			continue
+		}
+		for _, endpoint := range entry.Endpoints {
+			if endpoint.Region != "" && !strSliceContains(regions, endpoint.Region) {
+				regions = append(regions, endpoint.Region)
+			}
+		}
+	}
+
+	if len(regions) == 0 {
+		return fmt.Errorf("the service catalog has no %q service", eo.Type)
+	}
+	if !strSliceContains(regions, eo.Region) {
+		sort.Strings(regions)
+		return fmt.Errorf("the %q region doesn't provide the %q service, valid regions are: %s", eo.Region, eo.Type, strings.Join(regions, ", "))
+	}
+
+	return nil
+}
+
+// exactlyOne returns the only match or an error. On ambiguity the error lists
+// the conflicting matches, formatted by describe, to help narrowing down the
+// filter.
+func exactlyOne[T any](matches []T, kind string, describe func(T) string) (T, error) {
+	var zero T
+	switch len(matches) {
+	case 0:
+		return zero, fmt.Errorf("no %s found", kind)
+	case 1:
+		return matches[0], nil
+	}
+
+	const maxListed = 10
+	list := make([]string, 0, maxListed+1)
+	for i, m := range matches {
+		if i == maxListed {
+			list = append(list, fmt.Sprintf("and %d more", len(matches)-maxListed))
+			break
+		}
+		list = append(list, describe(m))
+	}
+
+	return zero, fmt.Errorf("more than one %s found, narrow down the filter: %s", kind, strings.Join(list, ", "))
+}
+
+// describeMatch formats the ID and the optional name of a match.
+func describeMatch(id, name string) string {
+	if name == "" {
+		return id
+	}
+	return fmt.Sprintf("%s (%s)", id, name)
+}
+
 // sliceContains returns true if the element exists in the slice.
 func sliceContains[T comparable](sl []T, el T) bool {
 	for _, s := range sl {
@@ -142,6 +238,29 @@ func validateKubernetesVersion(v any, k string) ([]string, []error) {
 	return nil, nil
 }
 
+func validateURL(v any, k string) ([]string, []error) {
+	u, err := url.ParseRequestURI(v.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("invalid URL (%s) specified for %s: %v", v.(string), k, err)}
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, []error{fmt.Errorf("invalid URL (%s) specified for %s: the scheme must be either http or https", v.(string), k)}
+	}
+	if u.Host == "" {
+		return nil, []error{fmt.Errorf("invalid URL (%s) specified for %s: the host is missing", v.(string), k)}
+	}
+
+	return nil, nil
+}
+
+func validateDuration(v any, k string) ([]string, []error) {
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("invalid duration (%s) specified for %s: %v", v.(string), k, err)}
+	}
+
+	return nil, nil
+}
+
 func removePrefixIPAddress(ip string) string {
 	res, _, _ := net.ParseCIDR(ip)
 	if res == nil {